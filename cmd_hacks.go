@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 
+	"github.com/BurntSushi/gribble"
+
 	"github.com/BurntSushi/wingo/commands"
 	"github.com/BurntSushi/wingo/wm"
 )
@@ -11,6 +13,7 @@ func newHacks() wm.CommandHacks {
 	return wm.CommandHacks{
 		MouseResizeDirection:     mouseResizeDirection,
 		CycleClientRunWithKeyStr: cycleClientRunWithKeyStr,
+		Run:                      commands.Run,
 	}
 }
 
@@ -29,11 +32,23 @@ func cycleClientRunWithKeyStr(keyStr, cmdStr string) (func(), error) {
 		return nil, err
 	}
 
+	// The key binding calls run in its own goroutine, so the cycle prompt
+	// has to be shown by a job on the main event loop.
 	switch t := cmd.(type) {
 	case *commands.CycleClientNext:
-		run = func() { t.RunWithKeyStr(keyStr) }
+		run = func() {
+			commands.Exec(func() gribble.Value {
+				t.RunWithKeyStr(keyStr)
+				return nil
+			})
+		}
 	case *commands.CycleClientPrev:
-		run = func() { t.RunWithKeyStr(keyStr) }
+		run = func() {
+			commands.Exec(func() gribble.Value {
+				t.RunWithKeyStr(keyStr)
+				return nil
+			})
+		}
 	default:
 		panic(fmt.Sprintf("bug: unknown type %T", t))
 	}
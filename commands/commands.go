@@ -6,7 +6,6 @@ import (
 	"os/exec"
 	"regexp"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/BurntSushi/gribble"
@@ -19,6 +18,8 @@ import (
 	"github.com/BurntSushi/xgbutil/xrect"
 
 	"github.com/BurntSushi/wingo/focus"
+	"github.com/BurntSushi/wingo/hook"
+	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/logger"
 	"github.com/BurntSushi/wingo/misc"
 	"github.com/BurntSushi/wingo/wm"
@@ -65,9 +66,11 @@ var Env = gribble.New([]gribble.Command{
 	&Quit{},
 	&SetLayout{},
 	&SetOpacity{},
+	&SetPlacement{},
 	&Script{},
 	&ScriptConfig{},
 	&Shell{},
+	&StopHook{},
 	&Unfloat{},
 	&Unmaximize{},
 	&WingoExec{},
@@ -94,6 +97,31 @@ var Env = gribble.New([]gribble.Command{
 	&AutoMastersMore{},
 	&AutoMastersFewer{},
 
+	&ManualTile{},
+	&ManualSplitHorizontal{},
+	&ManualSplitVertical{},
+	&ManualMoveLeft{},
+	&ManualMoveRight{},
+	&ManualMoveUp{},
+	&ManualMoveDown{},
+	&ManualResize{},
+
+	&PaperColumnWidth{},
+	&PaperColumnLeft{},
+	&PaperColumnRight{},
+	&PaperStack{},
+	&PaperUnstack{},
+
+	&GapsSet{},
+	&GapsAdjust{},
+
+	&SnapLeft{},
+	&SnapRight{},
+	&SnapTopLeft{},
+	&SnapTopRight{},
+	&SnapBottomLeft{},
+	&SnapBottomRight{},
+
 	&CycleClientChoose{},
 	&CycleClientHide{},
 	&CycleClientNext{},
@@ -127,12 +155,17 @@ var Env = gribble.New([]gribble.Command{
 	&GetWorkspacePrefix{},
 	&GetWorkspacePrev{},
 	&GetClientStatesList{},
+	&GetTree{},
 	&HideClientFromPanels{},
 	&ShowClientInPanels{},
 
 	&TagGet{},
 	&TagSet{},
 
+	&WaitForManaged{},
+	&WaitForFocused{},
+	&WaitForVisible{},
+
 	&True{},
 	&False{},
 	&MatchClientMapped{},
@@ -141,23 +174,23 @@ var Env = gribble.New([]gribble.Command{
 	&MatchClientIsTransient{},
 	&MatchClientName{},
 	&MatchClientType{},
+	&MatchClientClassRegex{},
+	&MatchClientInstanceRegex{},
+	&MatchClientNameRegex{},
+	&MatchClientRole{},
+	&MatchClientRoleRegex{},
+	&MatchClientPid{},
+	&MatchClientProcess{},
+	&MatchClientMachine{},
+	&MatchClientWindowType{},
+	&MatchClientWorkspace{},
+	&MatchClientProperty{},
 	&Not{},
 	&And{},
 	&Or{},
 })
 
 var (
-	// SafeExec is a channel through which a Gribble command execution is
-	// sent and executed synchronously with respect to the X main event loop.
-	// This is necessary to allow asynchronous prompts to run and return
-	// values without locking up the rest of the window manager.
-	SafeExec = make(chan func() gribble.Value, 1)
-
-	// SafeReturn is the means through which a return value from a Gribble
-	// command is synchronously returned with respext to the X main event loop.
-	// See SafeExec.
-	SafeReturn = make(chan gribble.Value, 0)
-
 	// Regex for enforcing tag name constraints.
 	validTagName = regexp.MustCompile("^[-a-zA-Z0-9_]+$")
 )
@@ -169,18 +202,6 @@ func init() {
 	Env.Verbose = false
 }
 
-var syncLock = new(sync.Mutex)
-
-// syncRun should wrap the execution of most Gribble commands to ensure
-// synchronous execution with respect to the main X event loop.
-func syncRun(f func() gribble.Value) gribble.Value {
-	syncLock.Lock()
-	defer syncLock.Unlock()
-
-	SafeExec <- f
-	return <-SafeReturn
-}
-
 type AddWorkspace struct {
 	Name string `param:"1"`
 	Help string `
@@ -192,13 +213,11 @@ The name of the workspace that was added is returned.
 }
 
 func (cmd AddWorkspace) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		if err := wm.AddWorkspace(cmd.Name); err != nil {
-			wm.PopupError("Could not add workspace '%s': %s", cmd.Name, err)
-			return ""
-		}
-		return cmd.Name
-	})
+	if err := wm.AddWorkspace(cmd.Name); err != nil {
+		wm.PopupError("Could not add workspace '%s': %s", cmd.Name, err)
+		return ""
+	}
+	return cmd.Name
 }
 
 type Close struct {
@@ -211,12 +230,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Close) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.Close()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.Close()
 	})
+	return nil
 }
 
 type Dale struct {
@@ -255,12 +272,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Float) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.Float()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.Float()
 	})
+	return nil
 }
 
 type Focus struct {
@@ -273,30 +288,28 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Focus) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		return withClient(cmd.Client, func(c *xclient.Client) {
-			if c == nil {
-				focus.Root()
-
-				// Use the mouse coordinates to find which workspace it was
-				// clicked in. If a workspace can be found (i.e., no clicks in
-				// dead areas), then activate it.
-				xc, rw := wm.X.Conn(), wm.X.RootWin()
-				qp, err := xproto.QueryPointer(xc, rw).Reply()
-				if err != nil {
-					logger.Warning.Printf("Could not query pointer: %s", err)
-					return
-				}
-
-				geom := xrect.New(int(qp.RootX), int(qp.RootY), 1, 1)
-				if wrk := wm.Heads.FindMostOverlap(geom); wrk != nil {
-					wm.SetWorkspace(wrk, false)
-				}
-			} else {
-				c.Focus()
-				xevent.ReplayPointer(wm.X)
+	return withClient(cmd.Client, func(c *xclient.Client) {
+		if c == nil {
+			focus.Root()
+
+			// Use the mouse coordinates to find which workspace it was
+			// clicked in. If a workspace can be found (i.e., no clicks in
+			// dead areas), then activate it.
+			xc, rw := wm.X.Conn(), wm.X.RootWin()
+			qp, err := xproto.QueryPointer(xc, rw).Reply()
+			if err != nil {
+				logger.Warning.Printf("Could not query pointer: %s", err)
+				return
 			}
-		})
+
+			geom := xrect.New(int(qp.RootX), int(qp.RootY), 1, 1)
+			if wrk := wm.Heads.FindMostOverlap(geom); wrk != nil {
+				wm.SetWorkspace(wrk, false)
+			}
+		} else {
+			c.Focus()
+			xevent.ReplayPointer(wm.X)
+		}
 	})
 }
 
@@ -310,12 +323,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd FocusRaise) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		return withClient(cmd.Client, func(c *xclient.Client) {
-			c.Focus()
-			c.Raise()
-			xevent.ReplayPointer(wm.X)
-		})
+	return withClient(cmd.Client, func(c *xclient.Client) {
+		c.Focus()
+		c.Raise()
+		xevent.ReplayPointer(wm.X)
 	})
 }
 
@@ -329,12 +340,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd FrameBorders) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.FrameBorders()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.FrameBorders()
 	})
+	return nil
 }
 
 type FrameFull struct {
@@ -347,12 +356,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd FrameFull) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.FrameFull()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.FrameFull()
 	})
+	return nil
 }
 
 type FrameNada struct {
@@ -365,12 +372,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd FrameNada) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.FrameNada()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.FrameNada()
 	})
+	return nil
 }
 
 type FrameSlim struct {
@@ -383,12 +388,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd FrameSlim) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.FrameSlim()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.FrameSlim()
 	})
+	return nil
 }
 
 type HeadCycle struct {
@@ -399,16 +402,14 @@ by their physical position: left to right and then top to bottom.
 }
 
 func (cmd HeadCycle) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		cur := wm.Heads.VisibleIndex(wm.Workspace())
-		next := misc.Mod(cur+1, wm.Heads.NumHeads())
-		wm.Heads.WithVisibleWorkspace(next,
-			func(wrk *workspace.Workspace) {
-				wm.SetWorkspace(wrk, false)
-			})
-		wm.FocusFallback()
-		return nil
-	})
+	cur := wm.Heads.VisibleIndex(wm.Workspace())
+	next := misc.Mod(cur+1, wm.Heads.NumHeads())
+	wm.Heads.WithVisibleWorkspace(next,
+		func(wrk *workspace.Workspace) {
+			wm.SetWorkspace(wrk, false)
+		})
+	wm.FocusFallback()
+	return nil
 }
 
 type HeadFocus struct {
@@ -420,14 +421,12 @@ by their physical position: left to right and then top to bottom.
 }
 
 func (cmd HeadFocus) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		wm.Heads.WithVisibleWorkspace(cmd.Head,
-			func(wrk *workspace.Workspace) {
-				wm.SetWorkspace(wrk, false)
-			})
-		wm.FocusFallback()
-		return nil
-	})
+	wm.Heads.WithVisibleWorkspace(cmd.Head,
+		func(wrk *workspace.Workspace) {
+			wm.SetWorkspace(wrk, false)
+		})
+	wm.FocusFallback()
+	return nil
 }
 
 type HeadFocusWithClient struct {
@@ -443,17 +442,15 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd HeadFocusWithClient) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			wm.Heads.WithVisibleWorkspace(cmd.Head,
-				func(wrk *workspace.Workspace) {
-					wm.SetWorkspace(wrk, false)
-					wrk.Add(c)
-					c.Raise()
-				})
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		wm.Heads.WithVisibleWorkspace(cmd.Head,
+			func(wrk *workspace.Workspace) {
+				wm.SetWorkspace(wrk, false)
+				wrk.Add(c)
+				c.Raise()
+			})
 	})
+	return nil
 }
 
 type ToggleFloating struct {
@@ -467,12 +464,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd ToggleFloating) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.FloatingToggle()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.FloatingToggle()
 	})
+	return nil
 }
 
 type ToggleIconify struct {
@@ -486,12 +481,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd ToggleIconify) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.IconifyToggle()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.IconifyToggle()
 	})
+	return nil
 }
 
 type Iconify struct {
@@ -505,12 +498,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Iconify) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.Iconify()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.Iconify()
 	})
+	return nil
 }
 
 type Deiconify struct {
@@ -524,12 +515,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Deiconify) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.Deiconify()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.Deiconify()
 	})
+	return nil
 }
 
 type ToggleMaximize struct {
@@ -542,12 +531,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd ToggleMaximize) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.MaximizeToggle()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.MaximizeToggle()
 	})
+	return nil
 }
 
 type ToggleStackAbove struct {
@@ -562,12 +549,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd ToggleStackAbove) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.StackAboveToggle()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.StackAboveToggle()
 	})
+	return nil
 }
 
 type ToggleStackBelow struct {
@@ -582,12 +567,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd ToggleStackBelow) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.StackBelowToggle()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.StackBelowToggle()
 	})
+	return nil
 }
 
 type ToggleSticky struct {
@@ -602,12 +585,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd ToggleSticky) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.StickyToggle()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.StickyToggle()
 	})
+	return nil
 }
 
 type Maximize struct {
@@ -621,12 +602,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Maximize) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.Maximize()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.Maximize()
 	})
+	return nil
 }
 
 type MouseMove struct {
@@ -679,11 +658,9 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Raise) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		return withClient(cmd.Client, func(c *xclient.Client) {
-			c.Raise()
-			xevent.ReplayPointer(wm.X)
-		})
+	return withClient(cmd.Client, func(c *xclient.Client) {
+		c.Raise()
+		xevent.ReplayPointer(wm.X)
 	})
 }
 
@@ -704,18 +681,16 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Move) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		x, xok := parsePos(wm.Workspace().Geom(), cmd.X, false)
-		y, yok := parsePos(wm.Workspace().Geom(), cmd.Y, true)
-		if !xok || !yok {
-			return nil
-		}
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.EnsureUnmax()
-			c.LayoutMove(x, y)
-		})
+	x, xok := parsePos(wm.Workspace().Geom(), cmd.X, false)
+	y, yok := parsePos(wm.Workspace().Geom(), cmd.Y, true)
+	if !xok || !yok {
 		return nil
+	}
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.EnsureUnmax()
+		c.LayoutMove(x, y)
 	})
+	return nil
 }
 
 type MoveRelative struct {
@@ -736,19 +711,17 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd MoveRelative) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		geom := wm.Workspace().Geom()
-		x, xok := parsePos(geom, cmd.X, false)
-		y, yok := parsePos(geom, cmd.Y, true)
-		if !xok || !yok {
-			return nil
-		}
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.EnsureUnmax()
-			c.LayoutMove(geom.X()+x, geom.Y()+y)
-		})
+	geom := wm.Workspace().Geom()
+	x, xok := parsePos(geom, cmd.X, false)
+	y, yok := parsePos(geom, cmd.Y, true)
+	if !xok || !yok {
 		return nil
+	}
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.EnsureUnmax()
+		c.LayoutMove(geom.X()+x, geom.Y()+y)
 	})
+	return nil
 }
 
 type MovePointer struct {
@@ -761,11 +734,9 @@ origin is located in the top left corner.
 }
 
 func (cmd MovePointer) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		xproto.WarpPointer(wm.X.Conn(), 0, wm.X.RootWin(), 0, 0, 0, 0,
-			int16(cmd.X), int16(cmd.Y))
-		return nil
-	})
+	xproto.WarpPointer(wm.X.Conn(), 0, wm.X.RootWin(), 0, 0, 0, 0,
+		int16(cmd.X), int16(cmd.Y))
+	return nil
 }
 
 type MovePointerRelative struct {
@@ -783,17 +754,15 @@ workspace's geometry.
 }
 
 func (cmd MovePointerRelative) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		geom := wm.Workspace().Geom()
-		x, xok := parsePos(geom, cmd.X, false)
-		y, yok := parsePos(geom, cmd.Y, true)
-		if !xok || !yok {
-			return nil
-		}
-		xproto.WarpPointer(wm.X.Conn(), 0, wm.X.RootWin(), 0, 0, 0, 0,
-			int16(geom.X()+x), int16(geom.Y()+y))
+	geom := wm.Workspace().Geom()
+	x, xok := parsePos(geom, cmd.X, false)
+	y, yok := parsePos(geom, cmd.Y, true)
+	if !xok || !yok {
 		return nil
-	})
+	}
+	xproto.WarpPointer(wm.X.Conn(), 0, wm.X.RootWin(), 0, 0, 0, 0,
+		int16(geom.X()+x), int16(geom.Y()+y))
+	return nil
 }
 
 type Restart struct {
@@ -804,11 +773,9 @@ after you've made changes to its configuration.
 }
 
 func (cmd Restart) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		wm.Restart = true // who says globals are bad?
-		xevent.Quit(wm.X)
-		return nil
-	})
+	wm.Restart = true // who says globals are bad?
+	xevent.Quit(wm.X)
+	return nil
 }
 
 type Quit struct {
@@ -818,11 +785,9 @@ Stops Wingo.
 }
 
 func (cmd Quit) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		logger.Message.Println("The User has told us to quit.")
-		xevent.Quit(wm.X)
-		return nil
-	})
+	logger.Message.Println("The User has told us to quit.")
+	xevent.Quit(wm.X)
+	return nil
 }
 
 type SetLayout struct {
@@ -841,12 +806,10 @@ name.
 }
 
 func (cmd SetLayout) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			wrk.SetLayout(cmd.Name)
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		wrk.SetLayout(cmd.Name)
 	})
+	return nil
 }
 
 type SetOpacity struct {
@@ -867,18 +830,53 @@ completely transparent and 1.0 is completely opaque.
 }
 
 func (cmd SetOpacity) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		if cmd.Opacity < 0.0 || cmd.Opacity > 1.0 {
-			logger.Warning.Printf(
-				"Opacity %f is not in the range [0, 1].", cmd.Opacity)
-			return nil
-		}
-		withClient(cmd.Client, func(c *xclient.Client) {
-			// Opacity is set on the top-most frame window of the client.
-			ewmh.WmWindowOpacitySet(wm.X, c.Frame().Parent().Id, cmd.Opacity)
-		})
+	if cmd.Opacity < 0.0 || cmd.Opacity > 1.0 {
+		logger.Warning.Printf(
+			"Opacity %f is not in the range [0, 1].", cmd.Opacity)
 		return nil
+	}
+	withClient(cmd.Client, func(c *xclient.Client) {
+		// Opacity is set on the top-most frame window of the client.
+		ewmh.WmWindowOpacitySet(wm.X, c.Frame().Parent().Id, cmd.Opacity)
 	})
+	return nil
+}
+
+type SetPlacement struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Policy string      `param:"2"`
+	Help   string      `
+Sets the policy used to place the window specified by Client in the floating
+layout, overriding the floating_placement option for that window only. If the
+window is floating on a visible workspace, it is placed again right away. This
+is most useful in a "managed" hook, to place particular windows differently.
+
+Client may be the window id or a substring that matches a window name.
+
+Policy must be one of "smart", "cascade", "centered", "under-pointer",
+"transient-parent" or "random".
+`
+}
+
+func (cmd SetPlacement) Run() gribble.Value {
+	policy, ok := layout.PlacementPolicy(cmd.Policy)
+	if !ok {
+		logger.Warning.Printf(
+			"Unknown floating placement policy '%s'.", cmd.Policy)
+		return nil
+	}
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.PlacementPolicySet(policy)
+
+		wrk, ok := c.Workspace().(*workspace.Workspace)
+		if !ok || !wrk.IsVisible() {
+			return
+		}
+		if _, ok := c.Layout().(*layout.Floating); ok {
+			wrk.LayoutFloater().InitialPlacement(c)
+		}
+	})
+	return nil
 }
 
 type RemoveWorkspace struct {
@@ -893,18 +891,16 @@ name.
 }
 
 func (cmd RemoveWorkspace) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if err := wm.RemoveWorkspace(wrk); err != nil {
-				wm.PopupError("Could not remove workspace '%s': %s", wrk, err)
-				return
-			}
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if err := wm.RemoveWorkspace(wrk); err != nil {
+			wm.PopupError("Could not remove workspace '%s': %s", wrk, err)
+			return
+		}
 
-			wm.FYI("Workspace %s removed.", wrk)
-			wm.FocusFallback()
-		})
-		return nil
+		wm.FYI("Workspace %s removed.", wrk)
+		wm.FocusFallback()
 	})
+	return nil
 }
 
 type RenameWorkspace struct {
@@ -920,18 +916,16 @@ NewName can only be a string.
 }
 
 func (cmd RenameWorkspace) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			oldName := wrk.String()
-			if err := wm.RenameWorkspace(wrk, cmd.NewName); err != nil {
-				wm.PopupError("Could not rename workspace '%s': %s", wrk, err)
-				return
-			}
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		oldName := wrk.String()
+		if err := wm.RenameWorkspace(wrk, cmd.NewName); err != nil {
+			wm.PopupError("Could not rename workspace '%s': %s", wrk, err)
+			return
+		}
 
-			wm.FYI("Workspace %s renamed to %s.", oldName, cmd.NewName)
-		})
-		return nil
+		wm.FYI("Workspace %s renamed to %s.", oldName, cmd.NewName)
 	})
+	return nil
 }
 
 type Resize struct {
@@ -951,18 +945,16 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Resize) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		w, wok := parseDim(wm.Workspace().Geom(), cmd.Width, false)
-		h, hok := parseDim(wm.Workspace().Geom(), cmd.Height, true)
-		if !wok || !hok {
-			return nil
-		}
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.EnsureUnmax()
-			c.LayoutResize(w, h)
-		})
+	w, wok := parseDim(wm.Workspace().Geom(), cmd.Width, false)
+	h, hok := parseDim(wm.Workspace().Geom(), cmd.Height, true)
+	if !wok || !hok {
 		return nil
+	}
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.EnsureUnmax()
+		c.LayoutResize(w, h)
 	})
+	return nil
 }
 
 type Script struct {
@@ -1082,6 +1074,23 @@ func fixScannerBugs(s string) string {
 	return s
 }
 
+type StopHook struct {
+	Help string `
+Stops the hook group being fired once the current hook has finished. Hooks
+later in the group, including those with a lower priority, are not run.
+
+This command only makes sense in the commands of a hook in hooks.wini. It has
+no effect anywhere else.
+`
+}
+
+func (cmd StopHook) Run() gribble.Value {
+	if !hook.Stop() {
+		logger.Warning.Printf("StopHook can only be used by a hook.")
+	}
+	return nil
+}
+
 type Unfloat struct {
 	Client gribble.Any `param:"1" types:"int,string"`
 	Help   string      `
@@ -1093,12 +1102,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Unfloat) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.Unfloat()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.Unfloat()
 	})
+	return nil
 }
 
 type Unmaximize struct {
@@ -1112,12 +1119,10 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd Unmaximize) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.Unmaximize()
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.Unmaximize()
 	})
+	return nil
 }
 
 type WingoExec struct {
@@ -1166,13 +1171,11 @@ name.
 }
 
 func (cmd Workspace) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			wm.SetWorkspace(wrk, false)
-			wm.FocusFallback()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		wm.SetWorkspace(wrk, false)
+		wm.FocusFallback()
 	})
+	return nil
 }
 
 type WorkspaceGreedy struct {
@@ -1191,13 +1194,11 @@ name.
 }
 
 func (cmd WorkspaceGreedy) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			wm.SetWorkspace(wrk, true)
-			wm.FocusFallback()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		wm.SetWorkspace(wrk, true)
+		wm.FocusFallback()
 	})
+	return nil
 }
 
 type WorkspaceHead struct {
@@ -1215,13 +1216,11 @@ name.
 }
 
 func (cmd WorkspaceHead) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		index := -1
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			index = wm.Heads.VisibleIndex(wrk)
-		})
-		return index
+	index := -1
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		index = wm.Heads.VisibleIndex(wrk)
 	})
+	return index
 }
 
 type WorkspaceSendClient struct {
@@ -1238,14 +1237,12 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd WorkspaceSendClient) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			withClient(cmd.Client, func(c *xclient.Client) {
-				wrk.Add(c)
-			})
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		withClient(cmd.Client, func(c *xclient.Client) {
+			wrk.Add(c)
 		})
-		return nil
 	})
+	return nil
 }
 
 type WorkspaceToHead struct {
@@ -1264,13 +1261,11 @@ to right and then top to bottom.
 }
 
 func (cmd WorkspaceToHead) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			wm.WorkspaceToHead(cmd.Head, wrk)
-			wm.FocusFallback()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		wm.WorkspaceToHead(cmd.Head, wrk)
+		wm.FocusFallback()
 	})
+	return nil
 }
 
 type WorkspaceWithClient struct {
@@ -1288,17 +1283,15 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd WorkspaceWithClient) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			withClient(cmd.Client, func(c *xclient.Client) {
-				c.Raise()
-				wrk.Add(c)
-				wm.SetWorkspace(wrk, false)
-				wm.FocusFallback()
-			})
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		withClient(cmd.Client, func(c *xclient.Client) {
+			c.Raise()
+			wrk.Add(c)
+			wm.SetWorkspace(wrk, false)
+			wm.FocusFallback()
 		})
-		return nil
 	})
+	return nil
 }
 
 type WorkspaceGreedyWithClient struct {
@@ -1320,17 +1313,15 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd WorkspaceGreedyWithClient) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			withClient(cmd.Client, func(c *xclient.Client) {
-				c.Raise()
-				wrk.Add(c)
-				wm.SetWorkspace(wrk, true)
-				wm.FocusFallback()
-			})
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		withClient(cmd.Client, func(c *xclient.Client) {
+			c.Raise()
+			wrk.Add(c)
+			wm.SetWorkspace(wrk, true)
+			wm.FocusFallback()
 		})
-		return nil
 	})
+	return nil
 }
 
 type TagGet struct {
@@ -1401,7 +1392,7 @@ func (cmd TagSet) Run() gribble.Value {
 	err := xprop.ChangeProp(wm.X, cid, 8, tagName, "UTF8_STRING",
 		[]byte(cmd.Value))
 	if err != nil {
-		return cmdError(err.Error())
+		return cmdError("%s", err)
 	}
 	return ""
 }
@@ -1417,13 +1408,11 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd HideClientFromPanels) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.SkipTaskbarSet(true)
-			c.SkipPagerSet(true)
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.SkipTaskbarSet(true)
+		c.SkipPagerSet(true)
 	})
+	return nil
 }
 
 type ShowClientInPanels struct {
@@ -1437,11 +1426,9 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd ShowClientInPanels) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withClient(cmd.Client, func(c *xclient.Client) {
-			c.SkipTaskbarSet(false)
-			c.SkipPagerSet(false)
-		})
-		return nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		c.SkipTaskbarSet(false)
+		c.SkipPagerSet(false)
 	})
+	return nil
 }
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"github.com/BurntSushi/gribble"
+
+	"github.com/BurntSushi/wingo/layout"
+	"github.com/BurntSushi/wingo/wm"
+	"github.com/BurntSushi/wingo/workspace"
+)
+
+// withGapsWorkspaces is like withWorkspace, except the special workspace name
+// ":all:" runs f for every workspace.
+func withGapsWorkspaces(wArg gribble.Any, f func(wrk *workspace.Workspace)) {
+	if name, ok := wArg.(string); ok && name == ":all:" {
+		for _, wrk := range wm.Heads.Workspaces.Wrks {
+			f(wrk)
+		}
+		return
+	}
+	withWorkspace(wArg, f)
+}
+
+type GapsSet struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Inner     int         `param:"2"`
+	Outer     int         `param:"3"`
+	Help      string      `
+Sets the gaps used by tiling layouts on the workspace specified by Workspace.
+Inner is the space in pixels between tiled windows, and Outer is the space in
+pixels between tiled windows and the edge of the workspace.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name. If Workspace is ":all:", the gaps are set on every workspace.
+`
+}
+
+func (cmd GapsSet) Run() gribble.Value {
+	withGapsWorkspaces(cmd.Workspace, func(wrk *workspace.Workspace) {
+		wrk.GapsSet(layout.Gaps{Inner: cmd.Inner, Outer: cmd.Outer})
+	})
+	return nil
+}
+
+type GapsAdjust struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Inner     int         `param:"2"`
+	Outer     int         `param:"3"`
+	Help      string      `
+Grows or shrinks the gaps used by tiling layouts on the workspace specified by
+Workspace. Inner and Outer are added to the current inner and outer gaps, and
+may be negative. Gaps never shrink below zero.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name. If Workspace is ":all:", the gaps are adjusted on every workspace.
+`
+}
+
+func (cmd GapsAdjust) Run() gribble.Value {
+	withGapsWorkspaces(cmd.Workspace, func(wrk *workspace.Workspace) {
+		gaps := wrk.Gaps()
+		gaps.Inner += cmd.Inner
+		gaps.Outer += cmd.Outer
+		wrk.GapsSet(gaps)
+	})
+	return nil
+}
@@ -58,16 +58,14 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd GetClientX) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		x := -9999
-		withClient(cmd.Client, func(c *xclient.Client) {
-			if c.IsMapped() {
-				origin := c.Workspace().Geom()
-				x = c.Geom().X() - origin.X()
-			}
-		})
-		return x
+	x := -9999
+	withClient(cmd.Client, func(c *xclient.Client) {
+		if c.IsMapped() {
+			origin := c.Workspace().Geom()
+			x = c.Geom().X() - origin.X()
+		}
 	})
+	return x
 }
 
 type GetClientY struct {
@@ -87,16 +85,14 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd GetClientY) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		y := -9999
-		withClient(cmd.Client, func(c *xclient.Client) {
-			if c.IsMapped() {
-				origin := c.Workspace().Geom()
-				y = c.Geom().Y() - origin.Y()
-			}
-		})
-		return y
+	y := -9999
+	withClient(cmd.Client, func(c *xclient.Client) {
+		if c.IsMapped() {
+			origin := c.Workspace().Geom()
+			y = c.Geom().Y() - origin.Y()
+		}
 	})
+	return y
 }
 
 type GetClientHeight struct {
@@ -110,13 +106,11 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd GetClientHeight) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		height := 0
-		withClient(cmd.Client, func(c *xclient.Client) {
-			height = c.Geom().Height()
-		})
-		return height
+	height := 0
+	withClient(cmd.Client, func(c *xclient.Client) {
+		height = c.Geom().Height()
 	})
+	return height
 }
 
 type GetClientWidth struct {
@@ -130,13 +124,11 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd GetClientWidth) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		width := 0
-		withClient(cmd.Client, func(c *xclient.Client) {
-			width = c.Geom().Width()
-		})
-		return width
+	width := 0
+	withClient(cmd.Client, func(c *xclient.Client) {
+		width = c.Geom().Width()
 	})
+	return width
 }
 
 type GetAllClients struct {
@@ -147,13 +139,11 @@ in the order in which they were managed, starting with the oldest client.
 }
 
 func (cmd GetAllClients) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		cids := make([]string, len(wm.Clients))
-		for i, client := range wm.Clients {
-			cids[i] = fmt.Sprintf("%d", client.Id())
-		}
-		return strings.Join(cids, "\n")
-	})
+	cids := make([]string, len(wm.Clients))
+	for i, client := range wm.Clients {
+		cids[i] = fmt.Sprintf("%d", client.Id())
+	}
+	return strings.Join(cids, "\n")
 }
 
 type GetClientList struct {
@@ -169,15 +159,13 @@ name.
 }
 
 func (cmd GetClientList) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		cids := make([]string, 0)
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			for _, client := range wrk.Clients {
-				cids = append(cids, fmt.Sprintf("%d", client.Id()))
-			}
-		})
-		return strings.Join(cids, "\n")
+	cids := make([]string, 0)
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		for _, client := range wrk.Clients {
+			cids = append(cids, fmt.Sprintf("%d", client.Id()))
+		}
 	})
+	return strings.Join(cids, "\n")
 }
 
 type GetClientName struct {
@@ -190,13 +178,11 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd GetClientName) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		name := ""
-		withClient(cmd.Client, func(c *xclient.Client) {
-			name = c.Name()
-		})
-		return name
+	name := ""
+	withClient(cmd.Client, func(c *xclient.Client) {
+		name = c.Name()
 	})
+	return name
 }
 
 type GetClientType struct {
@@ -210,13 +196,11 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd GetClientType) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		typ := ""
-		withClient(cmd.Client, func(c *xclient.Client) {
-			typ = c.PrimaryTypeString()
-		})
-		return typ
+	typ := ""
+	withClient(cmd.Client, func(c *xclient.Client) {
+		typ = c.PrimaryTypeString()
 	})
+	return typ
 }
 
 type GetClientWorkspace struct {
@@ -229,16 +213,14 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd GetClientWorkspace) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		var wrk workspace.Workspacer = nil
-		withClient(cmd.Client, func(c *xclient.Client) {
-			wrk = c.Workspace()
-		})
-		if wrk == nil {
-			return ""
-		}
-		return wrk.String()
+	var wrk workspace.Workspacer = nil
+	withClient(cmd.Client, func(c *xclient.Client) {
+		wrk = c.Workspace()
 	})
+	if wrk == nil {
+		return ""
+	}
+	return wrk.String()
 }
 
 type GetHead struct {
@@ -249,9 +231,7 @@ by their physical position: left to right and then top to bottom.
 }
 
 func (cmd GetHead) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		return wm.Heads.VisibleIndex(wm.Workspace())
-	})
+	return wm.Heads.VisibleIndex(wm.Workspace())
 }
 
 type GetNumHeads struct {
@@ -261,9 +241,7 @@ Returns the number of active Heads.
 }
 
 func (cmd GetNumHeads) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		return wm.Heads.NumHeads()
-	})
+	return wm.Heads.NumHeads()
 }
 
 type GetNumHeadsConnected struct {
@@ -274,9 +252,7 @@ than the number returned by GetNumHeads.
 }
 
 func (cmd GetNumHeadsConnected) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		return wm.Heads.NumConnected()
-	})
+	return wm.Heads.NumConnected()
 }
 
 type GetHeadHeight struct {
@@ -291,13 +267,11 @@ right and then top to bottom.
 }
 
 func (cmd GetHeadHeight) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		height := 0
-		wm.Heads.WithVisibleWorkspace(cmd.Head, func(wrk *workspace.Workspace) {
-			height = wm.Heads.Geom(wrk).Height()
-		})
-		return height
+	height := 0
+	wm.Heads.WithVisibleWorkspace(cmd.Head, func(wrk *workspace.Workspace) {
+		height = wm.Heads.Geom(wrk).Height()
 	})
+	return height
 }
 
 type GetHeadWidth struct {
@@ -312,13 +286,11 @@ right and then top to bottom.
 }
 
 func (cmd GetHeadWidth) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		width := 0
-		wm.Heads.WithVisibleWorkspace(cmd.Head, func(wrk *workspace.Workspace) {
-			width = wm.Heads.Geom(wrk).Width()
-		})
-		return width
+	width := 0
+	wm.Heads.WithVisibleWorkspace(cmd.Head, func(wrk *workspace.Workspace) {
+		width = wm.Heads.Geom(wrk).Width()
 	})
+	return width
 }
 
 type GetHeadWorkspace struct {
@@ -331,13 +303,11 @@ left to right and then top to bottom.
 }
 
 func (cmd GetHeadWorkspace) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		name := ""
-		wm.Heads.WithVisibleWorkspace(cmd.Head, func(wrk *workspace.Workspace) {
-			name = wrk.String()
-		})
-		return name
+	name := ""
+	wm.Heads.WithVisibleWorkspace(cmd.Head, func(wrk *workspace.Workspace) {
+		name = wrk.String()
 	})
+	return name
 }
 
 type GetLayout struct {
@@ -353,16 +323,14 @@ name.
 }
 
 func (cmd GetLayout) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		var w workspace.Workspacer = nil
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			w = wrk
-		})
-		if w == nil {
-			return ""
-		}
-		return w.LayoutName()
+	var w workspace.Workspacer = nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		w = wrk
 	})
+	if w == nil {
+		return ""
+	}
+	return w.LayoutName()
 }
 
 type GetWorkspace struct {
@@ -372,9 +340,7 @@ Returns the name of the current workspace.
 }
 
 func (cmd GetWorkspace) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		return wm.Workspace().Name
-	})
+	return wm.Workspace().Name
 }
 
 type GetWorkspaceId struct {
@@ -388,13 +354,11 @@ name.
 }
 
 func (cmd GetWorkspaceId) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		ind := -1
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			ind = wm.Heads.GlobalIndex(wrk)
-		})
-		return ind
+	ind := -1
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		ind = wm.Heads.GlobalIndex(wrk)
 	})
+	return ind
 }
 
 type GetWorkspaceList struct {
@@ -406,13 +370,11 @@ The special "Sticky" workspace is not included.
 }
 
 func (cmd GetWorkspaceList) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		wrks := make([]string, len(wm.Heads.Workspaces.Wrks))
-		for i, wrk := range wm.Heads.Workspaces.Wrks {
-			wrks[i] = wrk.Name
-		}
-		return strings.Join(wrks, "\n")
-	})
+	wrks := make([]string, len(wm.Heads.Workspaces.Wrks))
+	for i, wrk := range wm.Heads.Workspaces.Wrks {
+		wrks[i] = wrk.Name
+	}
+	return strings.Join(wrks, "\n")
 }
 
 type GetWorkspaceNext struct {
@@ -437,48 +399,46 @@ workspace starting with Prefix will be returned.
 }
 
 func (cmd GetWorkspacePrefix) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		hasPre := func(wrk *workspace.Workspace, prefix string) bool {
-			return strings.HasPrefix(strings.ToLower(wrk.Name), prefix)
-		}
-		preAndHidden := func(wrk *workspace.Workspace, prefix string) bool {
-			return !wrk.IsVisible() && hasPre(wrk, prefix)
-		}
-
-		needle := strings.ToLower(cmd.Prefix)
-		cur := wm.Workspace()
-		if hasPre(cur, needle) {
-			past := false
-			for _, wrk := range wm.Heads.Workspaces.Wrks {
-				if past {
-					if preAndHidden(wrk, needle) {
-						return wrk.Name
-					}
-					continue
-				}
-				if wrk == cur {
-					past = true
-				}
-			}
+	hasPre := func(wrk *workspace.Workspace, prefix string) bool {
+		return strings.HasPrefix(strings.ToLower(wrk.Name), prefix)
+	}
+	preAndHidden := func(wrk *workspace.Workspace, prefix string) bool {
+		return !wrk.IsVisible() && hasPre(wrk, prefix)
+	}
 
-			// Nothing? Now look for one before 'cur'...
-			for _, wrk := range wm.Heads.Workspaces.Wrks {
-				if wrk == cur { // we've gone too far...
-					return ""
-				}
+	needle := strings.ToLower(cmd.Prefix)
+	cur := wm.Workspace()
+	if hasPre(cur, needle) {
+		past := false
+		for _, wrk := range wm.Heads.Workspaces.Wrks {
+			if past {
 				if preAndHidden(wrk, needle) {
 					return wrk.Name
 				}
+				continue
 			}
-		} else {
-			for _, wrk := range wm.Heads.Workspaces.Wrks {
-				if preAndHidden(wrk, needle) {
-					return wrk.Name
-				}
+			if wrk == cur {
+				past = true
 			}
 		}
-		return ""
-	})
+
+		// Nothing? Now look for one before 'cur'...
+		for _, wrk := range wm.Heads.Workspaces.Wrks {
+			if wrk == cur { // we've gone too far...
+				return ""
+			}
+			if preAndHidden(wrk, needle) {
+				return wrk.Name
+			}
+		}
+	} else {
+		for _, wrk := range wm.Heads.Workspaces.Wrks {
+			if preAndHidden(wrk, needle) {
+				return wrk.Name
+			}
+		}
+	}
+	return ""
 }
 
 type GetWorkspacePrev struct {
@@ -509,18 +469,16 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd GetClientStatesList) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		states := make([]string, 0, 2)
-		prefix := "_NET_WM_STATE_"
-		withClient(cmd.Client, func(c *xclient.Client) {
-			for _, s := range c.WmStates() {
-				if !strings.HasPrefix(s, prefix) {
-					logger.Warning.Printf("Unknown WM state: %s", s)
-					continue
-				}
-				states = append(states, s[len(prefix):])
+	states := make([]string, 0, 2)
+	prefix := "_NET_WM_STATE_"
+	withClient(cmd.Client, func(c *xclient.Client) {
+		for _, s := range c.WmStates() {
+			if !strings.HasPrefix(s, prefix) {
+				logger.Warning.Printf("Unknown WM state: %s", s)
+				continue
 			}
-		})
-		return strings.Join(states, "\n")
+			states = append(states, s[len(prefix):])
+		}
 	})
+	return strings.Join(states, "\n")
 }
@@ -1,11 +1,22 @@
 package commands
 
 import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
 	"strings"
 
 	"github.com/BurntSushi/gribble"
 
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xproto"
+
+	"github.com/BurntSushi/xgbutil/ewmh"
+	"github.com/BurntSushi/xgbutil/xprop"
+
 	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/wm"
+	"github.com/BurntSushi/wingo/workspace"
 	"github.com/BurntSushi/wingo/xclient"
 )
 
@@ -19,13 +30,11 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd MatchClientMapped) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		matched := false
-		withClient(cmd.Client, func(c *xclient.Client) {
-			matched = c.IsMapped()
-		})
-		return boolToInt(matched)
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		matched = c.IsMapped()
 	})
+	return boolToInt(matched)
 }
 
 type MatchClientClass struct {
@@ -41,17 +50,15 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd MatchClientClass) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		matched := false
-		withClient(cmd.Client, func(c *xclient.Client) {
-			needle := strings.ToLower(cmd.Class)
-			haystack := strings.ToLower(c.Class().Class)
-			if strings.Contains(haystack, needle) {
-				matched = true
-			}
-		})
-		return boolToInt(matched)
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		needle := strings.ToLower(cmd.Class)
+		haystack := strings.ToLower(c.Class().Class)
+		if strings.Contains(haystack, needle) {
+			matched = true
+		}
 	})
+	return boolToInt(matched)
 }
 
 type MatchClientInstance struct {
@@ -67,17 +74,15 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd MatchClientInstance) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		matched := false
-		withClient(cmd.Client, func(c *xclient.Client) {
-			needle := strings.ToLower(cmd.Instance)
-			haystack := strings.ToLower(c.Class().Instance)
-			if strings.Contains(haystack, needle) {
-				matched = true
-			}
-		})
-		return boolToInt(matched)
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		needle := strings.ToLower(cmd.Instance)
+		haystack := strings.ToLower(c.Class().Instance)
+		if strings.Contains(haystack, needle) {
+			matched = true
+		}
 	})
+	return boolToInt(matched)
 }
 
 type MatchClientIsTransient struct {
@@ -92,13 +97,11 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd MatchClientIsTransient) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		matched := false
-		withClient(cmd.Client, func(c *xclient.Client) {
-			matched = c.IsTransient()
-		})
-		return boolToInt(matched)
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		matched = c.IsTransient()
 	})
+	return boolToInt(matched)
 }
 
 type MatchClientName struct {
@@ -114,17 +117,15 @@ Client may be the window id or a substring that matches a window name.
 }
 
 func (cmd MatchClientName) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		matched := false
-		withClient(cmd.Client, func(c *xclient.Client) {
-			needle := strings.ToLower(cmd.Name)
-			haystack := strings.ToLower(c.Name())
-			if strings.Contains(haystack, needle) {
-				matched = true
-			}
-		})
-		return boolToInt(matched)
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		needle := strings.ToLower(cmd.Name)
+		haystack := strings.ToLower(c.Name())
+		if strings.Contains(haystack, needle) {
+			matched = true
+		}
 	})
+	return boolToInt(matched)
 }
 
 type MatchClientType struct {
@@ -134,22 +135,341 @@ type MatchClientType struct {
 Returns 1 if the type of the window specified by Client matches the type
 named by Type, and otherwise returns 0.
 
-Valid window types are "Normal", "Dock" or "Desktop".
+Valid window types are "Normal", "Dock" or "Desktop". See
+MatchClientWindowType for other types of windows, like dialogs.
 
 Client may be the window id or a substring that matches a window name.
 `
 }
 
 func (cmd MatchClientType) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		matched := false
-		withClient(cmd.Client, func(c *xclient.Client) {
-			if strings.ToLower(cmd.Type) == c.PrimaryTypeString() {
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		if strings.ToLower(cmd.Type) == c.PrimaryTypeString() {
+			matched = true
+		}
+	})
+	return boolToInt(matched)
+}
+
+type MatchClientClassRegex struct {
+	Client  gribble.Any `param:"1" types:"int,string"`
+	Pattern string      `param:"2"`
+	Help    string      `
+Returns 1 if the "class" part of the WM_CLASS property on the window
+specified by Client matches the regular expression Pattern, and otherwise
+returns 0. The match is case sensitive, unless Pattern starts with "(?i)".
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientClassRegex) Run() gribble.Value {
+	return matchClientRegex(cmd.Client, cmd.Pattern,
+		func(c *xclient.Client) string { return c.Class().Class })
+}
+
+type MatchClientInstanceRegex struct {
+	Client  gribble.Any `param:"1" types:"int,string"`
+	Pattern string      `param:"2"`
+	Help    string      `
+Returns 1 if the "instance" part of the WM_CLASS property on the window
+specified by Client matches the regular expression Pattern, and otherwise
+returns 0. The match is case sensitive, unless Pattern starts with "(?i)".
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientInstanceRegex) Run() gribble.Value {
+	return matchClientRegex(cmd.Client, cmd.Pattern,
+		func(c *xclient.Client) string { return c.Class().Instance })
+}
+
+type MatchClientNameRegex struct {
+	Client  gribble.Any `param:"1" types:"int,string"`
+	Pattern string      `param:"2"`
+	Help    string      `
+Returns 1 if the name of the window specified by Client matches the regular
+expression Pattern, and otherwise returns 0. The match is case sensitive,
+unless Pattern starts with "(?i)".
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientNameRegex) Run() gribble.Value {
+	return matchClientRegex(cmd.Client, cmd.Pattern,
+		func(c *xclient.Client) string { return c.Name() })
+}
+
+type MatchClientRole struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Role   string      `param:"2"`
+	Help   string      `
+Returns 1 if the WM_WINDOW_ROLE property on the window specified by Client
+contains the substring specified by Role, and otherwise returns 0. The search
+is done case insensitively.
+
+Applications often use the role to tell apart windows with the same class. For
+example, the main windows of Firefox have the role "browser".
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientRole) Run() gribble.Value {
+	return matchClientSubstring(cmd.Client, cmd.Role,
+		func(c *xclient.Client) string { return c.Role() })
+}
+
+type MatchClientRoleRegex struct {
+	Client  gribble.Any `param:"1" types:"int,string"`
+	Pattern string      `param:"2"`
+	Help    string      `
+Returns 1 if the WM_WINDOW_ROLE property on the window specified by Client
+matches the regular expression Pattern, and otherwise returns 0. The match is
+case sensitive, unless Pattern starts with "(?i)".
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientRoleRegex) Run() gribble.Value {
+	return matchClientRegex(cmd.Client, cmd.Pattern,
+		func(c *xclient.Client) string { return c.Role() })
+}
+
+type MatchClientPid struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Pid    int         `param:"2"`
+	Help   string      `
+Returns 1 if the _NET_WM_PID property on the window specified by Client is
+equal to Pid, and otherwise returns 0.
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientPid) Run() gribble.Value {
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		pid, err := ewmh.WmPidGet(wm.X, c.Id())
+		matched = err == nil && int(pid) == cmd.Pid
+	})
+	return boolToInt(matched)
+}
+
+type MatchClientProcess struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Name   string      `param:"2"`
+	Help   string      `
+Returns 1 if the name of the process that owns the window specified by Client
+contains the substring specified by Name, and otherwise returns 0. The search
+is done case insensitively.
+
+The process is found with the _NET_WM_PID property, and its name is read from
+/proc. So this only works for clients running on the same machine as Wingo,
+and on systems with a Linux style /proc.
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientProcess) Run() gribble.Value {
+	return matchClientSubstring(cmd.Client, cmd.Name,
+		func(c *xclient.Client) string {
+			pid, err := ewmh.WmPidGet(wm.X, c.Id())
+			if err != nil {
+				return ""
+			}
+			return processName(pid)
+		})
+}
+
+type MatchClientMachine struct {
+	Client  gribble.Any `param:"1" types:"int,string"`
+	Machine string      `param:"2"`
+	Help    string      `
+Returns 1 if the WM_CLIENT_MACHINE property on the window specified by Client
+contains the substring specified by Machine, and otherwise returns 0. The
+search is done case insensitively. WM_CLIENT_MACHINE is the host name of the
+machine the client is running on.
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientMachine) Run() gribble.Value {
+	return matchClientSubstring(cmd.Client, cmd.Machine,
+		func(c *xclient.Client) string {
+			machine, _ := xprop.PropValStr(
+				xprop.GetProperty(wm.X, c.Id(), "WM_CLIENT_MACHINE"))
+			return machine
+		})
+}
+
+type MatchClientWindowType struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Type   string      `param:"2"`
+	Help   string      `
+Returns 1 if the window specified by Client has the EWMH window type named by
+Type, and otherwise returns 0. A window may have more than one type.
+
+Type is the name of the type without the "_NET_WM_WINDOW_TYPE_" prefix, like
+"Normal", "Dialog", "Utility", "Splash", "Toolbar", "Menu", "Dock" or
+"Desktop". It is matched case insensitively. (Unlike MatchClientType, which
+only knows about normal, dock and desktop windows.)
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientWindowType) Run() gribble.Value {
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		typ := "_NET_WM_WINDOW_TYPE_" + strings.ToUpper(cmd.Type)
+		for _, winType := range c.WindowTypes() {
+			if winType == typ {
 				matched = true
 			}
+		}
+	})
+	return boolToInt(matched)
+}
+
+type MatchClientWorkspace struct {
+	Client    gribble.Any `param:"1" types:"int,string"`
+	Workspace gribble.Any `param:"2" types:"int,string"`
+	Help      string      `
+Returns 1 if the window specified by Client is on the workspace specified by
+Workspace, and otherwise returns 0. Sticky windows are not on any workspace.
+
+Client may be the window id or a substring that matches a window name.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd MatchClientWorkspace) Run() gribble.Value {
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+			matched = c.Workspace() == wrk
 		})
-		return boolToInt(matched)
 	})
+	return boolToInt(matched)
+}
+
+type MatchClientProperty struct {
+	Client   gribble.Any `param:"1" types:"int,string"`
+	Property string      `param:"2"`
+	Value    string      `param:"3"`
+	Help     string      `
+Returns 1 if the X property named by Property on the window specified by
+Client has the value Value, and otherwise returns 0. If the property has more
+than one value (like a list of strings or atoms), then it matches if any of its
+values is equal to Value.
+
+Strings are compared as they are, atoms are compared by name and all other
+values are compared as decimal integers. For example, this matches windows
+that asked to be kept above others:
+
+    MatchClientProperty ":client:" "_NET_WM_STATE" "_NET_WM_STATE_ABOVE"
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd MatchClientProperty) Run() gribble.Value {
+	matched := false
+	withClient(cmd.Client, func(c *xclient.Client) {
+		for _, val := range propertyValues(c.Id(), cmd.Property) {
+			if val == cmd.Value {
+				matched = true
+			}
+		}
+	})
+	return boolToInt(matched)
+}
+
+// matchClientSubstring returns 1 if the string returned by field for the
+// client specified by cArg contains needle, case insensitively.
+func matchClientSubstring(cArg gribble.Any, needle string,
+	field func(c *xclient.Client) string) gribble.Value {
+
+	matched := false
+	withClient(cArg, func(c *xclient.Client) {
+		haystack := strings.ToLower(field(c))
+		matched = strings.Contains(haystack, strings.ToLower(needle))
+	})
+	return boolToInt(matched)
+}
+
+// matchClientRegex returns 1 if the string returned by field for the client
+// specified by cArg matches the regular expression pattern.
+func matchClientRegex(cArg gribble.Any, pattern string,
+	field func(c *xclient.Client) string) gribble.Value {
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return cmdError("Could not compile '%s': %s", pattern, err)
+	}
+	matched := false
+	withClient(cArg, func(c *xclient.Client) {
+		matched = re.MatchString(field(c))
+	})
+	return boolToInt(matched)
+}
+
+// processName returns the name of the process with id pid, or an empty
+// string if it can't be found.
+func processName(pid uint) string {
+	bs, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(bs))
+}
+
+// propertyValues returns every value of the property named prop on the
+// window wid as strings. Strings are returned as they are, atoms as their
+// names and everything else as decimal integers. If the property doesn't
+// exist, nil is returned.
+func propertyValues(wid xproto.Window, prop string) []string {
+	reply, err := xprop.GetProperty(wm.X, wid, prop)
+	if err != nil {
+		return nil
+	}
+
+	switch reply.Format {
+	case 8:
+		return strings.Split(strings.TrimRight(string(reply.Value), "\x00"),
+			"\x00")
+	case 16:
+		vals := make([]string, 0, len(reply.Value)/2)
+		for v := reply.Value; len(v) >= 2; v = v[2:] {
+			vals = append(vals, fmt.Sprintf("%d", xgb.Get16(v)))
+		}
+		return vals
+	case 32:
+		typ, _ := xprop.AtomName(wm.X, reply.Type)
+		if typ == "ATOM" {
+			names, err := xprop.PropValAtoms(wm.X, reply, nil)
+			if err != nil {
+				return nil
+			}
+			return names
+		}
+		nums, _ := xprop.PropValNums(reply, nil)
+		vals := make([]string, len(nums))
+		for i, num := range nums {
+			vals[i] = fmt.Sprintf("%d", num)
+		}
+		return vals
+	}
+	return nil
 }
 
 type True struct {
@@ -159,9 +479,7 @@ Always returns 1.
 }
 
 func (cmd True) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		return boolToInt(true)
-	})
+	return boolToInt(true)
 }
 
 type False struct {
@@ -171,9 +489,7 @@ Always returns 0.
 }
 
 func (cmd False) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		return boolToInt(false)
-	})
+	return boolToInt(false)
 }
 
 type Not struct {
@@ -187,14 +503,12 @@ If Op is not in {0, 1}, then a warning is logged and nil is returned.
 }
 
 func (cmd Not) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		if cmd.Op != 0 && cmd.Op != 1 {
-			logger.Warning.Printf(
-				"'Not' received a value not in {0, 1}: %d", cmd.Op)
-			return nil
-		}
-		return boolToInt(!intToBool(cmd.Op))
-	})
+	if cmd.Op != 0 && cmd.Op != 1 {
+		logger.Warning.Printf(
+			"'Not' received a value not in {0, 1}: %d", cmd.Op)
+		return nil
+	}
+	return boolToInt(!intToBool(cmd.Op))
 }
 
 type And struct {
@@ -208,19 +522,17 @@ If Op1 or Op2 is not in {0, 1}, then a warning is logged and nil is returned.
 }
 
 func (cmd And) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		if cmd.Op1 != 0 && cmd.Op1 != 1 {
-			logger.Warning.Printf(
-				"Op1 in 'Or' received a value not in {0, 1}: %d", cmd.Op1)
-			return nil
-		}
-		if cmd.Op2 != 0 && cmd.Op2 != 1 {
-			logger.Warning.Printf(
-				"Op2 in 'Or' received a value not in {0, 1}: %d", cmd.Op2)
-			return nil
-		}
-		return boolToInt(intToBool(cmd.Op1) && intToBool(cmd.Op2))
-	})
+	if cmd.Op1 != 0 && cmd.Op1 != 1 {
+		logger.Warning.Printf(
+			"Op1 in 'Or' received a value not in {0, 1}: %d", cmd.Op1)
+		return nil
+	}
+	if cmd.Op2 != 0 && cmd.Op2 != 1 {
+		logger.Warning.Printf(
+			"Op2 in 'Or' received a value not in {0, 1}: %d", cmd.Op2)
+		return nil
+	}
+	return boolToInt(intToBool(cmd.Op1) && intToBool(cmd.Op2))
 }
 
 type Or struct {
@@ -234,17 +546,15 @@ If Op1 or Op2 is not in {0, 1}, then a warning is logged and nil is returned.
 }
 
 func (cmd Or) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		if cmd.Op1 != 0 && cmd.Op1 != 1 {
-			logger.Warning.Printf(
-				"Op1 in 'Or' received a value not in {0, 1}: %d", cmd.Op1)
-			return nil
-		}
-		if cmd.Op2 != 0 && cmd.Op2 != 1 {
-			logger.Warning.Printf(
-				"Op2 in 'Or' received a value not in {0, 1}: %d", cmd.Op2)
-			return nil
-		}
-		return boolToInt(intToBool(cmd.Op1) || intToBool(cmd.Op2))
-	})
+	if cmd.Op1 != 0 && cmd.Op1 != 1 {
+		logger.Warning.Printf(
+			"Op1 in 'Or' received a value not in {0, 1}: %d", cmd.Op1)
+		return nil
+	}
+	if cmd.Op2 != 0 && cmd.Op2 != 1 {
+		logger.Warning.Printf(
+			"Op2 in 'Or' received a value not in {0, 1}: %d", cmd.Op2)
+		return nil
+	}
+	return boolToInt(intToBool(cmd.Op1) || intToBool(cmd.Op2))
 }
@@ -18,10 +18,8 @@ Activates the current choice in a cycle prompt.
 }
 
 func (cmd CycleClientChoose) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		wm.Prompts.Cycle.Choose()
-		return nil
-	})
+	wm.Prompts.Cycle.Choose()
+	return nil
 }
 
 type CycleClientHide struct{
@@ -31,10 +29,8 @@ Hides (i.e., cancels) the current cycle prompt.
 }
 
 func (cmd CycleClientHide) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		wm.Prompts.Cycle.Hide()
-		return nil
-	})
+	wm.Prompts.Cycle.Hide()
+	return nil
 }
 
 type CycleClientNext struct {
@@ -63,14 +59,11 @@ func (cmd CycleClientNext) Run() gribble.Value {
 }
 
 func (cmd CycleClientNext) RunWithKeyStr(keyStr string) {
-	syncRun(func() gribble.Value {
-		wm.ShowCycleClient(keyStr,
-			stringBool(cmd.OnlyActiveWorkspace),
-			stringBool(cmd.OnlyVisible),
-			stringBool(cmd.ShowIconified))
-		wm.Prompts.Cycle.Next()
-		return nil
-	})
+	wm.ShowCycleClient(keyStr,
+		stringBool(cmd.OnlyActiveWorkspace),
+		stringBool(cmd.OnlyVisible),
+		stringBool(cmd.ShowIconified))
+	wm.Prompts.Cycle.Next()
 }
 
 type CycleClientPrev struct {
@@ -99,14 +92,11 @@ func (cmd CycleClientPrev) Run() gribble.Value {
 }
 
 func (cmd CycleClientPrev) RunWithKeyStr(keyStr string) {
-	syncRun(func() gribble.Value {
-		wm.ShowCycleClient(keyStr,
-			stringBool(cmd.OnlyActiveWorkspace),
-			stringBool(cmd.OnlyVisible),
-			stringBool(cmd.ShowIconified))
-		wm.Prompts.Cycle.Prev()
-		return nil
-	})
+	wm.ShowCycleClient(keyStr,
+		stringBool(cmd.OnlyActiveWorkspace),
+		stringBool(cmd.OnlyVisible),
+		stringBool(cmd.ShowIconified))
+	wm.Prompts.Cycle.Prev()
 }
 
 type Input struct {
@@ -124,7 +114,7 @@ another command.
 }
 
 func (cmd Input) Run() gribble.Value {
-	inputted := make(chan string, 0)
+	inputted := make(chan string, 1)
 
 	response := func(inp *prompt.Input, text string) {
 		inputted <- text
@@ -138,7 +128,12 @@ func (cmd Input) Run() gribble.Value {
 		return ""
 	}
 
-	return <-inputted
+	var text string
+	if !await(func() { text = <-inputted }) {
+		wm.Prompts.Input.Hide()
+		return ""
+	}
+	return text
 }
 
 type Message struct {
@@ -201,13 +196,24 @@ func (cmd SelectClient) Run() gribble.Value {
 		data)
 
 	for {
-		select {
-		case clientId := <-selected:
-			return clientId
-		case <-time.After(10 * time.Second):
-			if !wm.Prompts.Slct.Showing() {
-				return ":void:"
+		var clientId int
+		ok := false
+		waited := await(func() {
+			select {
+			case clientId = <-selected:
+				ok = true
+			case <-time.After(10 * time.Second):
 			}
+		})
+		if !waited {
+			wm.Prompts.Slct.Hide()
+			return ":void:"
+		}
+		if ok {
+			return clientId
+		}
+		if !wm.Prompts.Slct.Showing() {
+			return ":void:"
 		}
 	}
 	panic("unreachable")
@@ -241,13 +247,24 @@ func (cmd SelectWorkspace) Run() gribble.Value {
 	wm.ShowSelectWorkspace(stringTabComp(cmd.TabCompletion), data)
 
 	for {
-		select {
-		case wrkName := <-selected:
-			return wrkName
-		case <-time.After(10 * time.Second):
-			if !wm.Prompts.Slct.Showing() {
-				return ""
+		var wrkName string
+		ok := false
+		waited := await(func() {
+			select {
+			case wrkName = <-selected:
+				ok = true
+			case <-time.After(10 * time.Second):
 			}
+		})
+		if !waited {
+			wm.Prompts.Slct.Hide()
+			return ""
+		}
+		if ok {
+			return wrkName
+		}
+		if !wm.Prompts.Slct.Showing() {
+			return ""
 		}
 	}
 	panic("unreachable")
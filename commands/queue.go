@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"github.com/BurntSushi/gribble"
+
+	"github.com/BurntSushi/wingo/logger"
+)
+
+// Every Gribble command is run on the main event loop, one at a time, so that
+// commands never run concurrently with X event handlers or with each other.
+// Whoever wants to run a command (an IPC client, a hook, a key binding) puts a
+// job on the Jobs queue with Exec and waits for it to finish. The main event
+// loop runs jobs in between X events.
+//
+// Gribble commands are only ever run by a job, or by Inline while an X event
+// is being handled. So a command is always on the main event loop already,
+// and never needs to queue a job of its own. Exec always queues, since any
+// goroutine calling it is by definition not the main event loop.
+
+var (
+	// Jobs is the queue of jobs to run on the main event loop. The main event
+	// loop should call every function received.
+	Jobs = make(chan func())
+
+	// inlined is positive while Inline is calling a function. Only that
+	// function can see it, since nothing else runs while an X event is being
+	// handled.
+	inlined int
+
+	// The ping channels of the main event loop. See SetEventLoop.
+	pingBefore, pingAfter chan struct{}
+)
+
+// SetEventLoop gives this package the ping channels returned by
+// xevent.MainPing. They are used to keep processing X events while a command
+// is waiting for something that needs them, like a user typing into a prompt.
+func SetEventLoop(before, after chan struct{}) {
+	pingBefore, pingAfter = before, after
+}
+
+// Exec runs f as a job on the main event loop, waits for it to finish and
+// returns its value. Exec must not be called from the main event loop, which
+// includes Gribble commands.
+func Exec(f func() gribble.Value) gribble.Value {
+	done := make(chan gribble.Value, 1)
+	Jobs <- func() {
+		done <- f()
+	}
+	return <-done
+}
+
+// Inline calls f right away as if it were a job on the main event loop. It
+// must only be called where nothing else can run at the same time, like an X
+// event handler or a job.
+func Inline(f func() gribble.Value) gribble.Value {
+	inlined++
+	defer func() { inlined-- }()
+
+	return f()
+}
+
+// waiter is a command that waits for something to happen, like a client
+// being managed. Waiting on the main event loop would hold up everything
+// else, so Run and RunMany call wait from their own goroutine instead of
+// calling Run. (The Run method of a waiter returns an error.)
+type waiter interface {
+	wait() gribble.Value
+}
+
+// Run runs the Gribble command cmd on the main event loop, and returns its
+// value. See RunMany.
+func Run(cmd string) (gribble.Value, error) {
+	return RunMany(cmd, false)
+}
+
+// RunMany runs the Gribble commands in cmds, which are separated by new lines
+// or semicolons, as a single job on the main event loop. The value of the last
+// command is returned. Since it's a single job, no other command can run in
+// between.
+//
+// The exception is a command that waits, like WaitForManaged. It ends the
+// job and waits in the calling goroutine. The commands after it are run as
+// another job once it's done.
+//
+// If verbose is true, errors are reported in more detail.
+func RunMany(cmds string, verbose bool) (gribble.Value, error) {
+	var (
+		val  gribble.Value
+		err  error
+		rest []gribble.Command
+		w    waiter
+	)
+
+	// runUntilWaiter runs commands in rest until it finds a waiter, which is
+	// left in w.
+	runUntilWaiter := func() {
+		for len(rest) > 0 {
+			cmd := rest[0]
+			rest = rest[1:]
+			if cmdWaiter, ok := cmd.(waiter); ok {
+				w = cmdWaiter
+				return
+			}
+			val = cmd.Run()
+		}
+	}
+
+	// Sub-commands are run while the commands are parsed, so parsing is done
+	// as part of the first job.
+	Exec(func() gribble.Value {
+		Env.Verbose = verbose
+		rest, err = Env.Commands(cmds)
+		Env.Verbose = false
+		if err == nil {
+			runUntilWaiter()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for w != nil {
+		val, w = w.wait(), nil
+		if len(rest) > 0 {
+			Exec(func() gribble.Value {
+				runUntilWaiter()
+				return nil
+			})
+		}
+	}
+	return val, nil
+}
+
+// await calls f, which should block until the user does something in a
+// prompt. f is run in its own goroutine while X events are processed, so that
+// the prompt can be used. No other job is run until f returns. await must be
+// called from a job on the main event loop.
+//
+// Inside Inline, X events can't be processed until the X event handler
+// returns, so await returns false without calling f.
+//
+// f must not touch any state owned by the main event loop.
+func await(f func()) bool {
+	if inlined > 0 {
+		logger.Warning.Printf("Prompts can't be used by synchronous hooks.")
+		return false
+	}
+
+	done := make(chan struct{})
+	go func() {
+		f()
+		close(done)
+	}()
+	for {
+		select {
+		case <-pingBefore:
+			<-pingAfter
+		case <-done:
+			return true
+		}
+	}
+}
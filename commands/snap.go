@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"github.com/BurntSushi/gribble"
+
+	"github.com/BurntSushi/wingo/xclient"
+)
+
+// snapRun snaps the client specified to place, which is one of the
+// xclient.Snap[...] constants.
+func snapRun(client gribble.Any, place int) gribble.Value {
+	withClient(client, func(c *xclient.Client) {
+		c.Snap(place)
+	})
+	return nil
+}
+
+type SnapLeft struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Help   string      `
+Makes the floating window specified by Client fill the left half of its head.
+Running this command again on the same window cycles its width through 1/2,
+1/3 and 2/3 of the head.
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd SnapLeft) Run() gribble.Value {
+	return snapRun(cmd.Client, xclient.SnapLeft)
+}
+
+type SnapRight struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Help   string      `
+Makes the floating window specified by Client fill the right half of its head.
+Running this command again on the same window cycles its width through 1/2,
+1/3 and 2/3 of the head.
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd SnapRight) Run() gribble.Value {
+	return snapRun(cmd.Client, xclient.SnapRight)
+}
+
+type SnapTopLeft struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Help   string      `
+Makes the floating window specified by Client fill the top-left quarter of its
+head. Running this command again on the same window cycles its width through
+1/2, 1/3 and 2/3 of the head.
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd SnapTopLeft) Run() gribble.Value {
+	return snapRun(cmd.Client, xclient.SnapTopLeft)
+}
+
+type SnapTopRight struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Help   string      `
+Makes the floating window specified by Client fill the top-right quarter of
+its head. Running this command again on the same window cycles its width
+through 1/2, 1/3 and 2/3 of the head.
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd SnapTopRight) Run() gribble.Value {
+	return snapRun(cmd.Client, xclient.SnapTopRight)
+}
+
+type SnapBottomLeft struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Help   string      `
+Makes the floating window specified by Client fill the bottom-left quarter of
+its head. Running this command again on the same window cycles its width
+through 1/2, 1/3 and 2/3 of the head.
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd SnapBottomLeft) Run() gribble.Value {
+	return snapRun(cmd.Client, xclient.SnapBottomLeft)
+}
+
+type SnapBottomRight struct {
+	Client gribble.Any `param:"1" types:"int,string"`
+	Help   string      `
+Makes the floating window specified by Client fill the bottom-right quarter of
+its head. Running this command again on the same window cycles its width
+through 1/2, 1/3 and 2/3 of the head.
+
+Client may be the window id or a substring that matches a window name.
+`
+}
+
+func (cmd SnapBottomRight) Run() gribble.Value {
+	return snapRun(cmd.Client, xclient.SnapBottomRight)
+}
@@ -20,12 +20,10 @@ name.
 }
 
 func (cmd AutoTile) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			wrk.LayoutStateSet(workspace.AutoTiling)
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		wrk.LayoutStateSet(workspace.AutoTiling)
 	})
+	return nil
 }
 
 type AutoUntile struct {
@@ -43,12 +41,10 @@ name.
 }
 
 func (cmd AutoUntile) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			wrk.LayoutStateSet(workspace.Floating)
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		wrk.LayoutStateSet(workspace.Floating)
 	})
+	return nil
 }
 
 type AutoCycle struct {
@@ -65,12 +61,10 @@ name.
 }
 
 func (cmd AutoCycle) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			wrk.AutoCycle()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		wrk.AutoCycle()
 	})
+	return nil
 }
 
 type AutoResizeMaster struct {
@@ -88,15 +82,13 @@ name.
 }
 
 func (cmd AutoResizeMaster) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().ResizeMaster(cmd.Amount)
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().ResizeMaster(cmd.Amount)
 	})
+	return nil
 }
 
 type AutoResizeWindow struct {
@@ -114,15 +106,13 @@ name.
 }
 
 func (cmd AutoResizeWindow) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().ResizeWindow(cmd.Amount)
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().ResizeWindow(cmd.Amount)
 	})
+	return nil
 }
 
 type AutoNext struct {
@@ -138,15 +128,13 @@ name.
 }
 
 func (cmd AutoNext) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().Next()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().Next()
 	})
+	return nil
 }
 
 type AutoPrev struct {
@@ -162,15 +150,13 @@ name.
 }
 
 func (cmd AutoPrev) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().Prev()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().Prev()
 	})
+	return nil
 }
 
 type AutoSwitchNext struct {
@@ -186,15 +172,13 @@ name.
 }
 
 func (cmd AutoSwitchNext) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().SwitchNext()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().SwitchNext()
 	})
+	return nil
 }
 
 type AutoSwitchPrev struct {
@@ -210,15 +194,13 @@ name.
 }
 
 func (cmd AutoSwitchPrev) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().SwitchPrev()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().SwitchPrev()
 	})
+	return nil
 }
 
 type AutoMaster struct {
@@ -235,15 +217,13 @@ name.
 }
 
 func (cmd AutoMaster) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().FocusMaster()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().FocusMaster()
 	})
+	return nil
 }
 
 type AutoMakeMaster struct {
@@ -260,15 +240,13 @@ name.
 }
 
 func (cmd AutoMakeMaster) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().MakeMaster()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().MakeMaster()
 	})
+	return nil
 }
 
 type AutoMastersFewer struct {
@@ -282,15 +260,13 @@ name.
 }
 
 func (cmd AutoMastersFewer) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().MastersFewer()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().MastersFewer()
 	})
+	return nil
 }
 
 type AutoMastersMore struct {
@@ -304,13 +280,11 @@ name.
 }
 
 func (cmd AutoMastersMore) Run() gribble.Value {
-	return syncRun(func() gribble.Value {
-		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
-			if wrk.State != workspace.AutoTiling {
-				return
-			}
-			wrk.LayoutAutoTiler().MastersMore()
-		})
-		return nil
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		wrk.LayoutAutoTiler().MastersMore()
 	})
+	return nil
 }
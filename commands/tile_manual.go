@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/BurntSushi/gribble"
+
+	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/workspace"
+)
+
+type ManualTile struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Initiates manual tiling on the workspace specified by Workspace. If manual
+tiling is already active, the layout will be re-placed.
+
+Note that this command has no effect if the workspace is not visible.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd ManualTile) Run() gribble.Value {
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		wrk.LayoutStateSet(workspace.ManualTiling)
+	})
+	return nil
+}
+
+type ManualSplitHorizontal struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Splits the current window horizontally in the manual layout on the workspace
+specified by Workspace. The next window opened will be placed to the right of
+the current window.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd ManualSplitHorizontal) Run() gribble.Value {
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.ManualTiling {
+			return
+		}
+		wrk.LayoutManualTiler().SplitHorizontal()
+	})
+	return nil
+}
+
+type ManualSplitVertical struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Splits the current window vertically in the manual layout on the workspace
+specified by Workspace. The next window opened will be placed below the
+current window.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd ManualSplitVertical) Run() gribble.Value {
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.ManualTiling {
+			return
+		}
+		wrk.LayoutManualTiler().SplitVertical()
+	})
+	return nil
+}
+
+type ManualMoveLeft struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Moves the current window to the left in the manual layout on the workspace
+specified by Workspace. If the window to the left is part of another
+container, the current window is moved into that container.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd ManualMoveLeft) Run() gribble.Value {
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.ManualTiling {
+			return
+		}
+		wrk.LayoutManualTiler().MoveLeft()
+	})
+	return nil
+}
+
+type ManualMoveRight struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Moves the current window to the right in the manual layout on the workspace
+specified by Workspace. If the window to the right is part of another
+container, the current window is moved into that container.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd ManualMoveRight) Run() gribble.Value {
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.ManualTiling {
+			return
+		}
+		wrk.LayoutManualTiler().MoveRight()
+	})
+	return nil
+}
+
+type ManualMoveUp struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Moves the current window up in the manual layout on the workspace specified
+by Workspace. If the window above is part of another container, the current
+window is moved into that container.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd ManualMoveUp) Run() gribble.Value {
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.ManualTiling {
+			return
+		}
+		wrk.LayoutManualTiler().MoveUp()
+	})
+	return nil
+}
+
+type ManualMoveDown struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Moves the current window down in the manual layout on the workspace specified
+by Workspace. If the window below is part of another container, the current
+window is moved into that container.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd ManualMoveDown) Run() gribble.Value {
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.ManualTiling {
+			return
+		}
+		wrk.LayoutManualTiler().MoveDown()
+	})
+	return nil
+}
+
+type ManualResize struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Dimension string      `param:"2"`
+	Amount    float64     `param:"3"`
+	Help      string      `
+Increases or decreases the width or height of the current window by Amount in
+the manual layout on the workspace specified by Workspace.
+
+Dimension must be either "Width" or "Height". Amount should be a ratio between
+0.0 and 1.0, and is measured with respect to the closest container that can be
+resized in the given dimension.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd ManualResize) Run() gribble.Value {
+	withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.ManualTiling {
+			return
+		}
+		switch strings.ToLower(cmd.Dimension) {
+		case "width":
+			wrk.LayoutManualTiler().ResizeWidth(cmd.Amount)
+		case "height":
+			wrk.LayoutManualTiler().ResizeHeight(cmd.Amount)
+		default:
+			logger.Warning.Printf(
+				"Unknown dimension '%s'. Valid dimensions are "+
+					"'Width' and 'Height'.", cmd.Dimension)
+		}
+	})
+	return nil
+}
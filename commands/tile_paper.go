@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"github.com/BurntSushi/gribble"
+
+	"github.com/BurntSushi/wingo/layout"
+	"github.com/BurntSushi/wingo/workspace"
+)
+
+// withScroller runs f with the current layout of the workspace given if the
+// workspace is auto tiling with a scrolling layout (like Paper). Otherwise,
+// nothing happens.
+func withScroller(wrkArg gribble.Any, f func(lay layout.Scroller)) {
+	withWorkspace(wrkArg, func(wrk *workspace.Workspace) {
+		if wrk.State != workspace.AutoTiling {
+			return
+		}
+		if lay, ok := wrk.LayoutAutoTiler().(layout.Scroller); ok {
+			f(lay)
+		}
+	})
+}
+
+type PaperColumnWidth struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Amount    float64     `param:"2"`
+	Help      string      `
+Increases or decreases the width of the current column by Amount in the Paper
+layout on the workspace specified by Workspace.
+
+Amount should be a ratio between 0.0 and 1.0 of the width of the head. A
+column is never narrower than 0.1 or wider than 1.0.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd PaperColumnWidth) Run() gribble.Value {
+	withScroller(cmd.Workspace, func(lay layout.Scroller) {
+		lay.ColumnWidth(cmd.Amount)
+	})
+	return nil
+}
+
+type PaperColumnLeft struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Moves the current column one place to the left in the Paper layout on the
+workspace specified by Workspace.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd PaperColumnLeft) Run() gribble.Value {
+	withScroller(cmd.Workspace, func(lay layout.Scroller) {
+		lay.ColumnMoveLeft()
+	})
+	return nil
+}
+
+type PaperColumnRight struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Moves the current column one place to the right in the Paper layout on the
+workspace specified by Workspace.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd PaperColumnRight) Run() gribble.Value {
+	withScroller(cmd.Workspace, func(lay layout.Scroller) {
+		lay.ColumnMoveRight()
+	})
+	return nil
+}
+
+type PaperStack struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Moves the current window into the column to its left in the Paper layout on
+the workspace specified by Workspace. The window is placed below the windows
+already in that column.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd PaperStack) Run() gribble.Value {
+	withScroller(cmd.Workspace, func(lay layout.Scroller) {
+		lay.ColumnStack()
+	})
+	return nil
+}
+
+type PaperUnstack struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Help      string      `
+Moves the current window out of its column and into a new column to the right
+of it in the Paper layout on the workspace specified by Workspace. This has no
+effect if the window is already alone in its column.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd PaperUnstack) Run() gribble.Value {
+	withScroller(cmd.Workspace, func(lay layout.Scroller) {
+		lay.ColumnUnstack()
+	})
+	return nil
+}
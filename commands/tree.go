@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/BurntSushi/xgb/xproto"
+
+	"github.com/BurntSushi/xgbutil/xprop"
+	"github.com/BurntSushi/xgbutil/xrect"
+
+	"github.com/BurntSushi/gribble"
+
+	"github.com/BurntSushi/wingo/focus"
+	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/stack"
+	"github.com/BurntSushi/wingo/wm"
+	"github.com/BurntSushi/wingo/workspace"
+	"github.com/BurntSushi/wingo/xclient"
+)
+
+type GetTree struct {
+	Help string `
+Returns a JSON document describing the entire state of Wingo: every head,
+every workspace and every client. It looks like this:
+
+	{
+		"ActiveWorkspace": "1",
+		"ActiveClient": 1234,
+		"Heads": [{"Geom": {...}, "Workarea": {...}, "Workspace": "1"}],
+		"Workspaces": [{"Name": "1", "Layout": "vertical",
+			"State": "autotiling", "Head": 0, "Clients": [1234]}],
+		"Clients": [{"Id": 1234, "Name": "...", "Class": "...",
+			"Instance": "...", "Type": "normal", "Workspace": "1",
+			"Geom": {...}, "ClientGeom": {...}, "Frame": "full",
+			"Floating": false, "Maximized": false, "Sticky": false,
+			"Iconified": false, "Fullscreen": false, "Layer": "default",
+			"Tags": {"name": "value"}}]
+	}
+
+Each geometry ({...}) has the fields X, Y, Width and Height. Head is -1 for
+workspaces that aren't visible. Clients are listed in the order in which they
+were managed, and the clients of a workspace are listed from most recently
+focused to least recently focused.
+`
+}
+
+type treeRect struct {
+	X, Y, Width, Height int
+}
+
+type treeHead struct {
+	Geom, Workarea treeRect
+	Workspace      string
+}
+
+type treeWorkspace struct {
+	Name    string
+	Layout  string
+	State   string
+	Head    int
+	Clients []xproto.Window
+}
+
+type treeClient struct {
+	Id                  xproto.Window
+	Name                string
+	Class, Instance     string
+	Type                string
+	Workspace           string
+	Geom, ClientGeom    treeRect
+	Frame               string
+	Floating, Maximized bool
+	Sticky, Iconified   bool
+	Fullscreen          bool
+	Layer               string
+	Tags                map[string]string
+}
+
+type tree struct {
+	ActiveWorkspace string
+	ActiveClient    xproto.Window
+	Heads           []treeHead
+	Workspaces      []treeWorkspace
+	Clients         []treeClient
+}
+
+func (cmd GetTree) Run() gribble.Value {
+	t := tree{
+		ActiveWorkspace: wm.Workspace().Name,
+		Heads:           make([]treeHead, 0),
+		Workspaces:      make([]treeWorkspace, 0),
+		Clients:         make([]treeClient, 0),
+	}
+	if focused := focus.Current(); focused != nil {
+		t.ActiveClient = focused.Id()
+	}
+
+	workareas := wm.Heads.Workareas()
+	visibles := wm.Heads.VisibleWorkspaces()
+	for i, geom := range wm.Heads.Geoms() {
+		head := treeHead{
+			Geom:     newTreeRect(geom),
+			Workarea: newTreeRect(workareas[i]),
+		}
+		if i < len(visibles) {
+			head.Workspace = visibles[i].Name
+		}
+		t.Heads = append(t.Heads, head)
+	}
+	for _, wrk := range wm.Heads.Workspaces.Wrks {
+		t.Workspaces = append(t.Workspaces, newTreeWorkspace(wrk))
+	}
+	for _, client := range wm.Clients {
+		t.Clients = append(t.Clients,
+			newTreeClient(client.(*xclient.Client)))
+	}
+
+	bs, err := json.Marshal(t)
+	if err != nil {
+		return cmdError("Could not encode tree: %s", err)
+	}
+	return string(bs)
+}
+
+func newTreeRect(r xrect.Rect) treeRect {
+	if r == nil {
+		return treeRect{}
+	}
+	x, y, w, h := xrect.Pieces(r)
+	return treeRect{x, y, w, h}
+}
+
+func newTreeWorkspace(wrk *workspace.Workspace) treeWorkspace {
+	tw := treeWorkspace{
+		Name:    wrk.Name,
+		Layout:  wrk.LayoutName(),
+		Head:    wm.Heads.VisibleIndex(wrk),
+		Clients: make([]xproto.Window, 0, len(wrk.Clients)),
+	}
+	switch wrk.State {
+	case workspace.Floating:
+		tw.State = "floating"
+	case workspace.AutoTiling:
+		tw.State = "autotiling"
+	case workspace.ManualTiling:
+		tw.State = "manualtiling"
+	}
+	for _, client := range wrk.Clients {
+		tw.Clients = append(tw.Clients, client.Id())
+	}
+	return tw
+}
+
+func newTreeClient(c *xclient.Client) treeClient {
+	tc := treeClient{
+		Id:         c.Id(),
+		Name:       c.Name(),
+		Type:       c.PrimaryTypeString(),
+		Geom:       newTreeRect(c.Frame().Geom()),
+		ClientGeom: newTreeRect(c.ClientGeom()),
+		Frame:      c.FrameName(),
+		Floating:   c.IsFloating(),
+		Maximized:  c.IsMaximized(),
+		Sticky:     c.IsSticky(),
+		Iconified:  c.Iconified(),
+		Fullscreen: c.IsFullscreen(),
+		Layer:      layerNames[c.Layer()],
+		Tags:       clientTags(c.Id()),
+	}
+	if class := c.Class(); class != nil {
+		tc.Class, tc.Instance = class.Class, class.Instance
+	}
+	if wrk := c.Workspace(); wrk != nil {
+		tc.Workspace = wrk.String()
+	}
+	return tc
+}
+
+var layerNames = map[int]string{
+	stack.LayerDesktop:    "desktop",
+	stack.LayerBelow:      "below",
+	stack.LayerDefault:    "default",
+	stack.LayerAbove:      "above",
+	stack.LayerDock:       "dock",
+	stack.LayerFullscreen: "fullscreen",
+}
+
+// clientTags returns every tag set on the window with TagSet, keyed by the
+// name of the tag.
+func clientTags(wid xproto.Window) map[string]string {
+	tags := make(map[string]string)
+	reply, err := xproto.ListProperties(wm.X.Conn(), wid).Reply()
+	if err != nil {
+		logger.Warning.Printf("Could not list properties of %d: %s", wid, err)
+		return tags
+	}
+
+	prefix := "_WINGO_TAG_"
+	for _, atom := range reply.Atoms {
+		name, err := xprop.AtomName(wm.X, atom)
+		if err != nil || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		val, err := xprop.PropValStr(xprop.GetProperty(wm.X, wid, name))
+		if err != nil {
+			logger.Warning.Println(err)
+			continue
+		}
+		tags[name[len(prefix):]] = val
+	}
+	return tags
+}
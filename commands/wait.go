@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/xgb/xproto"
+
+	"github.com/BurntSushi/gribble"
+
+	"github.com/BurntSushi/wingo/event"
+	"github.com/BurntSushi/wingo/workspace"
+)
+
+type WaitForManaged struct {
+	Match   string `param:"1"`
+	Timeout int    `param:"2"`
+	Help    string `
+Blocks until a new client is managed for which the command Match returns 1,
+and returns the id of that client. The special string ":client:" in Match is
+replaced with the id of each new client, just like in hooks. Match can be
+written between back quotes so that it may contain double quotes, as in
+MatchClientClass ":client:" "Firefox".
+
+Only clients managed after this command starts are considered, so it should
+be started before the program that creates the client.
+
+If Timeout is greater than 0 and no client has matched after Timeout
+milliseconds, an error is returned.
+
+Since this command blocks, it can only be run on its own (not as an argument
+of another command), like with wingo-cmd. It can't be used by hooks.
+`
+}
+
+func (cmd WaitForManaged) Run() gribble.Value {
+	return cannotWait("WaitForManaged")
+}
+
+func (cmd WaitForManaged) wait() gribble.Value {
+	return waitForClient(cmd.Match, cmd.Timeout,
+		func(ev event.Event) (xproto.Window, bool) {
+			if ev, ok := ev.(event.ManagedClient); ok {
+				return ev.Id, true
+			}
+			return 0, false
+		})
+}
+
+type WaitForFocused struct {
+	Match   string `param:"1"`
+	Timeout int    `param:"2"`
+	Help    string `
+Blocks until the active client changes to a client for which the command
+Match returns 1, and returns the id of that client. The special string
+":client:" in Match is replaced with the id of each newly focused client.
+Use "True" for Match to wait for any change of the active client.
+
+If Timeout is greater than 0 and no client has matched after Timeout
+milliseconds, an error is returned.
+
+Since this command blocks, it can only be run on its own (not as an argument
+of another command), like with wingo-cmd. It can't be used by hooks.
+`
+}
+
+func (cmd WaitForFocused) Run() gribble.Value {
+	return cannotWait("WaitForFocused")
+}
+
+func (cmd WaitForFocused) wait() gribble.Value {
+	return waitForClient(cmd.Match, cmd.Timeout,
+		func(ev event.Event) (xproto.Window, bool) {
+			if ev, ok := ev.(event.FocusedClient); ok {
+				return ev.Id, true
+			}
+			return 0, false
+		})
+}
+
+type WaitForVisible struct {
+	Workspace gribble.Any `param:"1" types:"int,string"`
+	Timeout   int         `param:"2"`
+	Help      string      `
+Blocks until the workspace specified by Workspace is visible on some head,
+and returns the name of the workspace. If the workspace is already visible,
+this command returns immediately.
+
+If Timeout is greater than 0 and the workspace isn't visible after Timeout
+milliseconds, an error is returned.
+
+Since this command blocks, it can only be run on its own (not as an argument
+of another command), like with wingo-cmd. It can't be used by hooks.
+
+Workspace may be a workspace index (integer) starting at 0, or a workspace
+name.
+`
+}
+
+func (cmd WaitForVisible) Run() gribble.Value {
+	return cannotWait("WaitForVisible")
+}
+
+func (cmd WaitForVisible) wait() gribble.Value {
+	// Subscribe before checking, so that the workspace can't become visible
+	// in between.
+	events, stop := event.Subscribe()
+	defer stop()
+
+	name, visible := "", false
+	Exec(func() gribble.Value {
+		withWorkspace(cmd.Workspace, func(wrk *workspace.Workspace) {
+			name, visible = wrk.Name, wrk.IsVisible()
+		})
+		return nil
+	})
+	if len(name) == 0 {
+		return cmdError("Could not find workspace '%v'.", cmd.Workspace)
+	}
+	if visible {
+		return name
+	}
+
+	timeout := waitTimeout(cmd.Timeout)
+	for {
+		select {
+		case ev := <-events:
+			changed, ok := ev.(event.ChangedVisibleWorkspace)
+			if !ok {
+				continue
+			}
+			for _, visibleName := range changed.Workspaces {
+				if visibleName == name {
+					return name
+				}
+			}
+		case <-timeout:
+			return cmdError("Workspace '%s' was not visible after %d "+
+				"milliseconds.", name, cmd.Timeout)
+		}
+	}
+}
+
+// waitForClient waits for an event about a client, as reported by client,
+// for which the command match returns 1. The id of the client is returned,
+// or an error if timeout milliseconds pass first. Only match is run on the
+// main event loop, once for each client.
+func waitForClient(match string, timeout int,
+	client func(ev event.Event) (xproto.Window, bool)) gribble.Value {
+
+	if err := Env.Check(match); err != nil {
+		return cmdError("Could not parse '%s': %s", match, err)
+	}
+
+	events, stop := event.Subscribe()
+	defer stop()
+
+	timeoutc := waitTimeout(timeout)
+	for {
+		select {
+		case ev := <-events:
+			id, ok := client(ev)
+			if !ok {
+				continue
+			}
+
+			// Like in hooks, the quotes around ":client:" are replaced too,
+			// so that the id is passed as an int.
+			cmd := strings.Replace(match, "\":client:\"",
+				fmt.Sprintf("%d", id), -1)
+			val, err := Run(cmd)
+			if err != nil {
+				return cmdError("Could not run '%s': %s", cmd, err)
+			}
+			if n, ok := val.(int); ok && n == 1 {
+				return int(id)
+			}
+		case <-timeoutc:
+			return cmdError("No client matched '%s' after %d milliseconds.",
+				match, timeout)
+		}
+	}
+}
+
+// cannotWait returns the error given by a command that waits when it's run
+// on the main event loop, where it can't wait.
+func cannotWait(name string) gribble.Value {
+	return cmdError("%s can only be run on its own, and not by a hook.", name)
+}
+
+// waitTimeout returns a channel that gets a value after the given number of
+// milliseconds, or a channel that never does if ms isn't positive.
+func waitTimeout(ms int) <-chan time.Time {
+	if ms <= 0 {
+		return nil
+	}
+	return time.After(time.Duration(ms) * time.Millisecond)
+}
@@ -12,10 +12,42 @@ type Restarting struct{}
 
 type Subscribed struct{}
 
+// Client describes a client at the time of an event. It is embedded in every
+// event about a client, so that subscribers don't need to ask for the
+// details of a client over the command socket.
+type Client struct {
+	Id                  xproto.Window
+	Name                string
+	Class               string
+	Instance            string
+	Workspace           string
+	X, Y, Width, Height int
+}
+
+// Head describes the geometry of a head, and the workspace visible on it.
+type Head struct {
+	X, Y, Width, Height int
+	Workspace           string
+}
+
 type (
-	ChangedWorkspace        struct{}
-	ChangedVisibleWorkspace struct{}
-	ChangedWorkspaceNames   struct{}
+	// ChangedWorkspace is sent when the active workspace changes. Head is
+	// the index of the head that the workspace is visible on.
+	ChangedWorkspace struct {
+		Workspace string
+		Previous  string
+		Head      int
+	}
+
+	// ChangedVisibleWorkspace is sent when the workspace visible on any
+	// head changes. Workspaces holds the name of the workspace on each head.
+	ChangedVisibleWorkspace struct {
+		Workspaces []string
+	}
+
+	ChangedWorkspaceNames struct {
+		Names []string
+	}
 
 	AddedWorkspace struct {
 		Name string
@@ -24,39 +56,110 @@ type (
 	RemovedWorkspace struct {
 		Name string
 	}
+
+	// ChangedHeads is sent when heads are added, removed or resized.
+	ChangedHeads struct {
+		Heads []Head
+	}
 )
 
 type (
 	FocusedClient struct {
-		Id xproto.Window
+		Client
 	}
 	UnfocusedClient struct {
-		Id xproto.Window
+		Client
 	}
 	MappedClient struct {
-		Id xproto.Window
+		Client
 	}
 	UnmappedClient struct {
-		Id xproto.Window
+		Client
 	}
 	ManagedClient struct {
-		Id xproto.Window
+		Client
 	}
 	UnmanagedClient struct {
-		Id xproto.Window
-		Name string
-		Workspace string
-		Class string
-		Instance string
+		Client
 	}
 	ChangedClientName struct {
-		Id xproto.Window
+		Client
 	}
+
+	// ChangedActiveClient is sent when a client is focused or unfocused.
+	// When no client is active, the Id of the client is 0.
 	ChangedActiveClient struct {
-		Id xproto.Window
+		Client
+	}
+
+	// ChangedClientGeometry is sent whenever a client is moved or resized.
+	ChangedClientGeometry struct {
+		Client
+	}
+
+	FloatedClient struct {
+		Client
+	}
+	UnfloatedClient struct {
+		Client
+	}
+	MaximizedClient struct {
+		Client
+	}
+	UnmaximizedClient struct {
+		Client
+	}
+	FullscreenedClient struct {
+		Client
+	}
+	UnfullscreenedClient struct {
+		Client
+	}
+
+	// ChangedClientUrgency is sent when a client starts or stops demanding
+	// attention.
+	ChangedClientUrgency struct {
+		Client
+		Urgent bool
 	}
 )
 
+// ChangedLayout is sent when the layout of a workspace changes. Old and New
+// are the names of the layouts.
 type ChangedLayout struct {
 	Workspace string
+	Old, New  string
+}
+
+// Dropped is sent to a subscriber that was too slow to keep up, after some
+// events couldn't be sent to it. First and Last are the sequence numbers of
+// the first and last events that were dropped. A subscriber that gets this
+// should ask for a Snapshot to get back in sync.
+type Dropped struct {
+	Count       int
+	First, Last uint64
+}
+
+// Workspace describes a workspace in a Snapshot. Head is the index of the
+// head that the workspace is visible on, or -1 if it is hidden.
+type Workspace struct {
+	Name   string
+	Layout string
+	Head   int
+}
+
+// Snapshot is the full state of the window manager. It is sent to a
+// subscriber that asks for it. Events with a sequence number greater than
+// that of the snapshot happened after the snapshot was taken.
+type Snapshot struct {
+	Workspaces      []Workspace
+	ActiveWorkspace string
+	Heads           []Head
+	Clients         []Client
+	ActiveClient    xproto.Window
 }
+
+// SnapshotFun builds a Snapshot of the current state. It is set by the
+// window manager when it starts up, and is always called on the main event
+// loop.
+var SnapshotFun func() Snapshot
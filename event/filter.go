@@ -0,0 +1,102 @@
+package event
+
+import (
+	"reflect"
+
+	"github.com/BurntSushi/xgb/xproto"
+)
+
+// Filter decides which events are sent to a subscriber. A subscriber sets its
+// filter by sending it as a null terminated JSON object on the notify socket,
+// at any time. (A subscriber may also send {"Snapshot": true} to be sent a
+// Snapshot, which leaves its filter alone.) For example:
+//
+//	{"Events": ["ChangedWorkspace", "ChangedClientName"], "Clients": [1234]}
+//
+// Events lists the names of the events to send. Clients lists the ids of the
+// clients that events must be about, and Workspaces lists the names of the
+// workspaces that events must be about. An empty list matches everything,
+// and events that aren't about any client (or workspace) always match
+// Clients (or Workspaces). So does a ChangedActiveClient event for when no
+// client is active. The Subscribed event is always sent, and is sent
+// again each time a new filter has been set. Dropped and Snapshot are always
+// sent too.
+type Filter struct {
+	Events     []string
+	Clients    []xproto.Window
+	Workspaces []string
+}
+
+// Matches returns true if ev should be sent to a subscriber with this filter.
+func (f Filter) Matches(ev Event) bool {
+	switch ev.(type) {
+	case Subscribed, Dropped, Snapshot:
+		return true
+	}
+	if len(f.Events) > 0 && !hasString(f.Events, eventName(ev)) {
+		return false
+	}
+	if id, ok := eventClient(ev); ok && len(f.Clients) > 0 {
+		found := false
+		for _, cid := range f.Clients {
+			if cid == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if wrk, ok := eventWorkspace(ev); ok && len(f.Workspaces) > 0 {
+		if !hasString(f.Workspaces, wrk) {
+			return false
+		}
+	}
+	return true
+}
+
+func eventName(ev Event) string {
+	return reflect.TypeOf(ev).Name()
+}
+
+// eventClient returns the id of the client that ev is about, if any. A
+// ChangedActiveClient event with an Id of 0 (no client is active anymore)
+// isn't about any client, so a subscriber still learns that its client lost
+// the focus.
+func eventClient(ev Event) (xproto.Window, bool) {
+	if ev, ok := ev.(ChangedActiveClient); ok && ev.Id == 0 {
+		return 0, false
+	}
+	f := reflect.ValueOf(ev).FieldByName("Id")
+	if !f.IsValid() {
+		return 0, false
+	}
+	id, ok := f.Interface().(xproto.Window)
+	return id, ok
+}
+
+// eventWorkspace returns the name of the workspace that ev is about, if any.
+func eventWorkspace(ev Event) (string, bool) {
+	switch ev := ev.(type) {
+	case AddedWorkspace:
+		return ev.Name, true
+	case RemovedWorkspace:
+		return ev.Name, true
+	}
+	f := reflect.ValueOf(ev).FieldByName("Workspace")
+	if !f.IsValid() {
+		return "", false
+	}
+	wrk, ok := f.Interface().(string)
+	return wrk, ok
+}
+
+func hasString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -1,31 +1,46 @@
 package event
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"net"
 	"os"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/BurntSushi/xgbutil"
 
 	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/misc"
 )
 
-var subs subscriptions
+var (
+	subs = manageSubscriptions()
+
+	// Runs a function on the main event loop and waits for it to finish.
+	exec func(f func())
+)
 
-func Notifier(X *xgbutil.XUtil, fp string) {
+// Notifier listens for subscribers on the notify socket. execFun must run a
+// function on the main event loop and wait for it to finish. It's used to
+// build snapshots.
+func Notifier(X *xgbutil.XUtil, fp string, execFun func(f func())) {
+	exec = execFun
 	fp = fp + "-notify"
 	os.Remove(fp)
 
 	listener, err := net.Listen("unix", fp)
 	if err != nil {
-		logger.Error.Fatalln("Could not start IPC event listener: %s", err)
+		logger.Error.Fatalf("Could not start IPC event listener: %s", err)
 	}
 	defer listener.Close()
 
-	subs = manageSubscriptions()
+	if err := os.Chmod(fp, 0600); err != nil {
+		logger.Warning.Printf("Could not set permissions of '%s': %s", fp, err)
+	}
 
 	for {
 		conn, err := listener.Accept()
@@ -33,28 +48,60 @@ func Notifier(X *xgbutil.XUtil, fp string) {
 			logger.Warning.Printf("Error accepting IPC event conn: %s", err)
 			continue
 		}
+		if err := misc.CheckPeer(conn); err != nil {
+			logger.Warning.Printf("Refusing IPC event conn: %s", err)
+			conn.Close()
+			continue
+		}
 		go handleSubscriber(conn)
 	}
 }
 
+// Notify sends ev to every subscriber. It should be called on the main event
+// loop, so that the sequence number of ev is in step with snapshots.
 func Notify(ev Event) {
-	if subs.notify == nil {
-		return
+	subs.notify <- sequenced{atomic.AddUint64(&lastSeq, 1), ev}
+}
+
+// Subscribe returns a channel that gets every event from now on, for use
+// within Wingo. Like any other subscriber, events are dropped if they aren't
+// read fast enough. The function returned must be called once the events
+// are no longer needed.
+func Subscribe() (<-chan Event, func()) {
+	sub := subs.subscribe()
+	events, done := make(chan Event), make(chan struct{})
+	go func() {
+		for sev := range sub.events {
+			select {
+			case events <- sev.ev:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return events, func() {
+		once.Do(func() {
+			close(done)
+			subs.unsubscribe(sub.id)
+		})
 	}
-	subs.notify <- ev
 }
 
 func handleSubscriber(conn net.Conn) {
 	defer conn.Close()
 
-	id, events := subs.subscribe()
-	defer subs.unsubscribe(id)
+	sub := subs.subscribe()
+	defer subs.unsubscribe(sub.id)
 
-	logger.Message.Printf("Accepted new event subscriber (id: %d).", id)
+	logger.Message.Printf("Accepted new event subscriber (id: %d).", sub.id)
 
 	encoder := json.NewEncoder(conn)
-	writeEvent := func(ev Event) error {
-		if err := encoder.Encode(eventToMap(ev)); err != nil {
+	writeEvent := func(seq uint64, ev Event) error {
+		m := eventToMap(ev)
+		m["Seq"] = seq
+		if err := encoder.Encode(m); err != nil {
 			return err
 		}
 		if _, err := fmt.Fprintf(conn, "%c", 0); err != nil {
@@ -63,57 +110,204 @@ func handleSubscriber(conn net.Conn) {
 		return nil
 	}
 
-	if err := writeEvent(Subscribed{}); err != nil {
+	// Messages that aren't events themselves get the sequence number of the
+	// last event sent out to subscribers.
+	writeMessage := func(ev Event) error {
+		return writeEvent(atomic.LoadUint64(&lastSeq), ev)
+	}
+	writeDropped := func() error {
+		if dropped, ok := sub.dropped.take(); ok {
+			return writeMessage(dropped)
+		}
+		return nil
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	requests, hungup := readRequests(conn, sub.id, stop)
+
+	var filter Filter
+	if err := writeMessage(Subscribed{}); err != nil {
 		logger.Warning.Printf("Error sending initial subscription: %s", err)
 		return
 	}
 	for {
 		select {
 		case <-time.After(5 * time.Second):
-			if err := writeEvent(Noop{}); err != nil {
+			if err := writeDropped(); err != nil {
+				logger.Warning.Printf("Error sending dropped events: %s", err)
+				return
+			}
+			if !filter.Matches(Noop{}) {
+				continue
+			}
+			if err := writeMessage(Noop{}); err != nil {
 				logger.Warning.Printf("Subscriber timed out: %s", err)
 				return
 			}
-		case ev := <-events:
-			if err := writeEvent(ev); err != nil {
+		case sev := <-sub.events:
+			if err := writeDropped(); err != nil {
+				logger.Warning.Printf("Error sending dropped events: %s", err)
+				return
+			}
+			if !filter.Matches(sev.ev) {
+				continue
+			}
+			if err := writeEvent(sev.seq, sev.ev); err != nil {
 				logger.Warning.Printf("Error sending event: %s", err)
 				return
 			}
+		case req := <-requests:
+			if req.Snapshot {
+				if SnapshotFun == nil {
+					logger.Warning.Printf("No snapshot available for "+
+						"subscriber (id: %d).", sub.id)
+					continue
+				}
+				// The snapshot and its sequence number are taken together on
+				// the main event loop, so no event can happen in between.
+				var snap Snapshot
+				var seq uint64
+				exec(func() {
+					snap = SnapshotFun()
+					seq = atomic.LoadUint64(&lastSeq)
+				})
+				if err := writeEvent(seq, snap); err != nil {
+					logger.Warning.Printf("Error sending snapshot: %s", err)
+					return
+				}
+				continue
+			}
+			filter = req.Filter
+			if err := writeMessage(Subscribed{}); err != nil {
+				logger.Warning.Printf("Error sending subscription: %s", err)
+				return
+			}
+		case <-hungup:
+			return
 		}
 	}
 }
 
+// request is a message sent by a subscriber. It asks for a Snapshot if
+// Snapshot is true, and sets a new filter otherwise.
+type request struct {
+	Filter
+	Snapshot bool
+}
+
+// readRequests reads requests sent by a subscriber and sends them on the
+// returned channel. The second channel is closed when the subscriber hangs
+// up. Reading stops when stop is closed.
+func readRequests(conn net.Conn, id int,
+	stop chan struct{}) (chan request, chan struct{}) {
+
+	requests, hungup := make(chan request), make(chan struct{})
+	go func() {
+		defer close(hungup)
+
+		reader := bufio.NewReader(conn)
+		for {
+			msg, err := reader.ReadString(0)
+			if err != nil {
+				return
+			}
+			msg = msg[:len(msg)-1] // get rid of null terminator
+
+			var req request
+			if err := json.Unmarshal([]byte(msg), &req); err != nil {
+				logger.Warning.Printf("Could not read request from "+
+					"subscriber (id: %d): %s", id, err)
+				continue
+			}
+			select {
+			case requests <- req:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return requests, hungup
+}
+
 // eventToMap converts an event struct into a map.
 // This is a terrible hack in order to inject the event name automatically.
+// Fields of embedded structs (like Client) are put in the map directly.
 func eventToMap(ev Event) map[string]interface{} {
 	rv := reflect.ValueOf(ev)
-	rt := rv.Type()
 	m := make(map[string]interface{})
 
-	m["EventName"] = rt.Name()
+	m["EventName"] = rv.Type().Name()
+	addFields(m, rv)
+	return m
+}
+
+func addFields(m map[string]interface{}, rv reflect.Value) {
+	rt := rv.Type()
 	nf := rv.NumField()
 	for i := 0; i < nf; i++ {
-		m[rt.Field(i).Name] = rv.Field(i).Interface()
+		if rt.Field(i).Anonymous {
+			addFields(m, rv.Field(i))
+		} else {
+			m[rt.Field(i).Name] = rv.Field(i).Interface()
+		}
 	}
-	return m
+}
+
+// lastSeq is the sequence number of the last event sent out to subscribers.
+// The first event has sequence number 1.
+var lastSeq uint64
+
+// sequenced is an event along with its sequence number.
+type sequenced struct {
+	seq uint64
+	ev  Event
 }
 
 type subscriptions struct {
-	add chan chan subscriber // sends info back on the given channel
+	add    chan chan subscriber // sends info back on the given channel
 	remove chan int
-	notify chan Event
+	notify chan sequenced
 }
 
 type subscriber struct {
-	id int
-	events chan Event
+	id      int
+	events  chan sequenced
+	dropped *dropped
+}
+
+// dropped keeps track of the events that couldn't be sent to a subscriber
+// since it was last told about dropped events.
+type dropped struct {
+	sync.Mutex
+	Dropped
 }
 
-func (ss subscriptions) subscribe() (int, chan Event) {
+func (d *dropped) add(seq uint64) {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.Count == 0 {
+		d.First = seq
+	}
+	d.Count++
+	d.Last = seq
+}
+
+// take returns the events dropped so far, if any, and forgets about them.
+func (d *dropped) take() (Dropped, bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	dropped := d.Dropped
+	d.Dropped = Dropped{}
+	return dropped, dropped.Count > 0
+}
+
+func (ss subscriptions) subscribe() subscriber {
 	recv := make(chan subscriber)
 	ss.add <- recv
-	scriber := <-recv
-	return scriber.id, scriber.events
+	return <-recv
 }
 
 func (ss subscriptions) unsubscribe(id int) {
@@ -122,31 +316,37 @@ func (ss subscriptions) unsubscribe(id int) {
 
 func manageSubscriptions() subscriptions {
 	nextId := int(1)
-	subscribed := make(map[int]chan Event)
+	subscribed := make(map[int]subscriber)
 	script := subscriptions{
 		make(chan chan subscriber),
 		make(chan int),
-		make(chan Event),
+		make(chan sequenced),
 	}
 
 	go func() {
 		for {
 			select {
 			case recv := <-script.add:
-				subscribed[nextId] = make(chan Event, 100)
-				recv <- subscriber{nextId, subscribed[nextId]}
+				subscribed[nextId] = subscriber{
+					id:      nextId,
+					events:  make(chan sequenced, 100),
+					dropped: &dropped{},
+				}
+				recv <- subscribed[nextId]
 				nextId++
 			case id := <-script.remove:
-				close(subscribed[id])
+				close(subscribed[id].events)
 				delete(subscribed, id)
 				logger.Message.Printf("Subscriber disconnected (id: %d).", id)
-			case ev := <-script.notify:
+			case sev := <-script.notify:
 				for _, subscriber := range subscribed {
 					// Do a non-blocking send so that we drop notifications
-					// when the client gets too busy (or fails).
+					// when the client gets too busy (or fails). The
+					// subscriber is told about it later.
 					select {
-					case subscriber <- ev:
+					case subscriber.events <- sev:
 					default:
+						subscriber.dropped.add(sev.seq)
 					}
 				}
 			}
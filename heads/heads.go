@@ -9,6 +9,7 @@ import (
 	"github.com/BurntSushi/xgbutil/xrect"
 	"github.com/BurntSushi/xgbutil/xwindow"
 
+	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/logger"
 	"github.com/BurntSushi/wingo/workspace"
 )
@@ -23,12 +24,14 @@ type Heads struct {
 	visibles   []*workspace.Workspace // Slice of all visible workspaces.
 }
 
-func NewHeads(X *xgbutil.XUtil, defaultLayout string) *Heads {
+func NewHeads(X *xgbutil.XUtil, defaultLayout string,
+	tabTheme *layout.TabTheme) *Heads {
+
 	hds := &Heads{
 		X:      X,
 		active: 0,
 	}
-	hds.Workspaces = workspace.NewWorkspaces(X, hds, defaultLayout)
+	hds.Workspaces = workspace.NewWorkspaces(X, hds, defaultLayout, tabTheme)
 	return hds
 }
 
@@ -138,7 +141,7 @@ func (hds *Heads) Reload(clients Clients) {
 
 		if oldActive != hds.visibles[hds.active] {
 			panic(fmt.Sprintf("BUG: Old active workspace %s is not the same "+
-				"as the new active workspace.",
+				"as the new active workspace %s.",
 				oldActive, hds.visibles[hds.active]))
 		}
 	}
@@ -252,6 +255,24 @@ func Convert(rect, src, dest xrect.Rect) xrect.Rect {
 	return xrect.New(nx, ny, nw, nh)
 }
 
+// Geoms returns the raw geometry of every head.
+func (hds *Heads) Geoms() []xrect.Rect {
+	geoms := make([]xrect.Rect, len(hds.geom))
+	for i := range geoms {
+		geoms[i] = hds.geom[i]
+	}
+	return geoms
+}
+
+// Workareas returns the geometry of every head with struts applied.
+func (hds *Heads) Workareas() []xrect.Rect {
+	geoms := make([]xrect.Rect, len(hds.workarea))
+	for i := range geoms {
+		geoms[i] = hds.workarea[i]
+	}
+	return geoms
+}
+
 // NumHeads returns the current number of heads that Wingo is using.
 func (hds *Heads) NumHeads() int {
 	return len(hds.geom)
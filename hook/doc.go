@@ -1,7 +1,8 @@
 /*
 package hook defines, reads and executes hooks in Wingo. This package must
-be initialized with a Gribble execution environment, and a file path to a
-configuration file specifying user defined hooks.
+be initialized with a Gribble execution environment, a function that runs
+commands on the main event loop, and a file path to a configuration file
+specifying user defined hooks.
 
 The hook package defines only a set number of hook groups that the user can use
 to execute arbitrary commands.
@@ -11,6 +12,15 @@ the appropriate hook group (and arguments if relevant). Adding a new hook group
 only requires a new constant and a new entry in the unexported 'groups'
 variable.
 
+Hooks in a group are run in order of priority. Synchronous hooks are run
+before Fire returns, and the rest are run later as a job on the main event
+loop. A hook may end its group early by calling Stop, which is done by the
+StopHook command.
+
+The timer, idle and active hook groups aren't fired by any action. Instead,
+StartTimers fires them on a schedule or when the idle time of the user crosses
+a threshold.
+
 Please see config/hooks.wini in the Wingo project directory for an explanation
 of how user defined hooks can be specified:
 https://github.com/BurntSushi/wingo/blob/master/config/hooks.wini
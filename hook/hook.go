@@ -2,7 +2,9 @@ package hook
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/gribble"
 
@@ -14,24 +16,66 @@ import (
 // Wingo. When that action happens, every hook in the corresponding group
 // is fired.
 const (
-	Startup   Type = "startup"
-	Restarted Type = "restart"
-	Managed   Type = "managed"
-	Focused   Type = "focused"
-	Unfocused Type = "unfocused"
+	Startup              Type = "startup"
+	Restarted            Type = "restart"
+	Quit                 Type = "quit"
+	Managed              Type = "managed"
+	Unmanaged            Type = "unmanaged"
+	Focused              Type = "focused"
+	Unfocused            Type = "unfocused"
+	Fullscreen           Type = "fullscreen"
+	Maximized            Type = "maximized"
+	Urgent               Type = "urgent"
+	TitleChanged         Type = "title_changed"
+	ClientMovedWorkspace Type = "client_moved_workspace"
+	WorkspaceChanged     Type = "workspace_changed"
+	LayoutChanged        Type = "layout_changed"
+	HeadAdded            Type = "head_added"
+	HeadRemoved          Type = "head_removed"
+	Timer                Type = "timer"
+	Idle                 Type = "idle"
+	Active               Type = "active"
 )
 
 var (
 	// A global corresponding to the Gribble execution environment.
 	gribbleEnv *gribble.Environment
 
+	// Runs a function on the main event loop and waits for it to finish.
+	exec func(f func() gribble.Value) gribble.Value
+
+	// Runs a function right away, as if it were on the main event loop. Used
+	// for synchronous hooks, which run inside X event handlers.
+	inline func(f func() gribble.Value) gribble.Value
+
+	// The number of groups being fired. It's more than one when a hook
+	// fires another group.
+	firing int
+
+	// Set by Stop to end the group being fired.
+	stopped bool
+
 	// A map from group constants to group values.
 	groups = map[Type]group{
-		Startup:   make(group, 0),
-		Restarted: make(group, 0),
-		Managed:   make(group, 0),
-		Focused:   make(group, 0),
-		Unfocused: make(group, 0),
+		Startup:              make(group, 0),
+		Restarted:            make(group, 0),
+		Quit:                 make(group, 0),
+		Managed:              make(group, 0),
+		Unmanaged:            make(group, 0),
+		Focused:              make(group, 0),
+		Unfocused:            make(group, 0),
+		Fullscreen:           make(group, 0),
+		Maximized:            make(group, 0),
+		Urgent:               make(group, 0),
+		TitleChanged:         make(group, 0),
+		ClientMovedWorkspace: make(group, 0),
+		WorkspaceChanged:     make(group, 0),
+		LayoutChanged:        make(group, 0),
+		HeadAdded:            make(group, 0),
+		HeadRemoved:          make(group, 0),
+		Timer:                make(group, 0),
+		Idle:                 make(group, 0),
+		Active:               make(group, 0),
 	}
 )
 
@@ -56,14 +100,42 @@ type hook struct {
 	// are fired if '(and satisfies[0] satisfies[1] ... satisfies[n-1])' is
 	// satisfied.
 	consequences []string
+
+	// Hooks with a higher priority run first. Hooks with the same priority
+	// run in the order they appear in the config file.
+	priority int
+
+	// When true, the hook runs before Fire returns. Otherwise, it runs later
+	// as a job on the main event loop.
+	synchronous bool
+
+	// How often a hook in the "timer" group fires. Zero when the hook uses
+	// a schedule instead.
+	interval time.Duration
+
+	// When a hook in the "timer" group fires. Nil when the hook uses an
+	// interval instead.
+	schedule *schedule
+
+	// How long the user must be idle before a hook in the "idle" group
+	// fires. A hook in the "active" group fires when the user becomes active
+	// after being idle for at least this long.
+	idleTime time.Duration
 }
 
-// Initializes the hooks package with a Gribble execution environment and
-// a file path to a wini formatted hooks configuration file. If the
+// Initializes the hooks package with a Gribble execution environment, a
+// function that runs another function on the main event loop, a function that
+// runs another function right away as if it were on the main event loop and a
+// file path to a wini formatted hooks configuration file. If the
 // initialization fails, only a warning is logged since hooks are not
 // essential for Wingo to run.
-func Initialize(env *gribble.Environment, fpath string) {
+func Initialize(env *gribble.Environment,
+	execFun, inlineFun func(f func() gribble.Value) gribble.Value,
+	fpath string) {
+
 	gribbleEnv = env
+	exec = execFun
+	inline = inlineFun
 
 	cdata, err := wini.Parse(fpath)
 	if err != nil {
@@ -75,6 +147,11 @@ func Initialize(env *gribble.Environment, fpath string) {
 			logger.Warning.Printf("Could not load hook '%s': %s", hookName, err)
 		}
 	}
+	for _, grp := range groups {
+		sort.SliceStable(grp, func(i, j int) bool {
+			return grp[i].priority > grp[j].priority
+		})
+	}
 }
 
 // Fire will attempt to run every hook in the group specified, while replacing
@@ -85,72 +162,147 @@ func Initialize(env *gribble.Environment, fpath string) {
 // of the consequences. If any of the match conditions are false, we stop
 // and condinue on to the next hook.
 //
-// Note that Fire immediately return a channel, as it executes in its own
-// goroutine. The channel can be used to block until Fire has finished.
-func Fire(hk Type, args Args) {
+// Synchronous hooks are run first, before Fire returns. So Fire must be
+// called where nothing else can run at the same time, like an X event handler
+// or a job on the main event loop. Fire then returns a channel, while the rest
+// of the hooks are run in their own goroutine. The channel is closed when
+// every hook has finished. The rest of the hooks are run as a single job on
+// the main event loop, so no other command can run in the middle of a hook.
+//
+// If a hook stops the group with Stop, no more hooks are run.
+func Fire(hk Type, args Args) <-chan struct{} {
+	done := make(chan struct{})
+	if _, ok := groups[hk]; !ok {
+		logger.Warning.Printf("Unknown hook group '%s'.", hk)
+		close(done)
+		return done
+	}
+
+	var sync, async group
+	for _, hook := range groups[hk] {
+		if hook.synchronous {
+			sync = append(sync, hook)
+		} else {
+			async = append(async, hook)
+		}
+	}
+	if len(sync) > 0 {
+		stop := false
+		inline(func() gribble.Value {
+			stop = fire(hk, sync, args)
+			return nil
+		})
+		if stop {
+			close(done)
+			return done
+		}
+	}
+	if len(async) == 0 {
+		close(done)
+		return done
+	}
 	go func() {
-		if _, ok := groups[hk]; !ok {
-			logger.Warning.Printf("Unknown hook group '%s'.", hk)
-			return
+		exec(func() gribble.Value {
+			fire(hk, async, args)
+			return nil
+		})
+		close(done)
+	}()
+	return done
+}
+
+// Stop ends the group being fired once the hook that called it has finished.
+// It returns false if no group is being fired. It must be run on the main
+// event loop.
+func Stop() bool {
+	if firing == 0 {
+		return false
+	}
+	stopped = true
+	return true
+}
+
+// fire runs each hook in hooks, which belong to the group hk, until one of
+// them calls Stop. It returns true if a hook called Stop. It must be run on
+// the main event loop.
+func fire(hk Type, hooks group, args Args) bool {
+	firing++
+	saved := stopped
+	stopped = false
+	defer func() {
+		firing--
+		stopped = saved
+	}()
+
+	for _, hook := range hooks {
+		runHook(hk, hook, args)
+		if stopped {
+			logger.Lots.Printf("The hook '%s' stopped the '%s' group.",
+				hook.name, hk)
+			return true
 		}
-		for _, hook := range groups[hk] {
-			// Run all of the match conditions. Depending upon the value
-			// of hk.conjunction, we treat the conditions as either a set
-			// of conjunctions or a set of disjunctions.
-			andMatched := true
-			orMatched := false
-			for _, condCmd := range hook.satisfies {
-				val, err := gribbleEnv.Run(args.apply(condCmd))
-				if err != nil {
-					logger.Warning.Printf("When executing the 'match' "+
-						"conditions for your '%s' hook in the '%s' group, "+
-						"the command '%s' returned an error: %s",
-						hook.name, hk, condCmd, err)
-					andMatched = false
-					orMatched = false
-					break
-				}
-				if gribbleBool(val) {
-					logger.Lots.Printf("Condition '%s' matched "+
-						"for the hook '%s' in the '%s' group.",
-						condCmd, hook.name, hk)
-					orMatched = true
-					if !hook.conjunction {
-						break
-					}
-				} else {
-					logger.Lots.Printf("Condition '%s' failed to match "+
-						"for the hook '%s' in the '%s' group.",
-						condCmd, hook.name, hk)
-					andMatched = false
-					if hook.conjunction {
-						break
-					}
-				}
-			}
-			if hook.conjunction && !andMatched {
-				continue
+	}
+	return false
+}
+
+// runHook runs the match conditions of a single hook in the group hk, and if
+// they match, runs its consequences. It must be run on the main event loop.
+func runHook(hk Type, hook hook, args Args) {
+	// Run all of the match conditions. Depending upon the value
+	// of hk.conjunction, we treat the conditions as either a set
+	// of conjunctions or a set of disjunctions.
+	andMatched := true
+	orMatched := false
+	for _, condCmd := range hook.satisfies {
+		val, err := gribbleEnv.Run(args.apply(condCmd))
+		if err != nil {
+			logger.Warning.Printf("When executing the 'match' "+
+				"conditions for your '%s' hook in the '%s' group, "+
+				"the command '%s' returned an error: %s",
+				hook.name, hk, condCmd, err)
+			andMatched = false
+			orMatched = false
+			break
+		}
+		if gribbleBool(val) {
+			logger.Lots.Printf("Condition '%s' matched "+
+				"for the hook '%s' in the '%s' group.",
+				condCmd, hook.name, hk)
+			orMatched = true
+			if !hook.conjunction {
+				break
 			}
-			if !hook.conjunction && !orMatched {
-				continue
+		} else {
+			logger.Lots.Printf("Condition '%s' failed to match "+
+				"for the hook '%s' in the '%s' group.",
+				condCmd, hook.name, hk)
+			andMatched = false
+			if hook.conjunction {
+				break
 			}
+		}
+	}
+	if hook.conjunction && !andMatched {
+		return
+	}
+	if !hook.conjunction && !orMatched {
+		return
+	}
 
-			logger.Lots.Printf("The hook '%s' in the '%s' group has matched!",
-				hook.name, hk)
+	logger.Lots.Printf("The hook '%s' in the '%s' group has matched!",
+		hook.name, hk)
 
-			// We have a match! Let's proceed to the consequences...
-			for _, consequentCmd := range hook.consequences {
-				_, err := gribbleEnv.Run(args.apply(consequentCmd))
-				if err != nil {
-					logger.Warning.Printf("When executing the consequences "+
-						"for your '%s' hook in the '%s' group, the command "+
-						"'%s' returned an error: %s",
-						hook.name, hk, consequentCmd, err)
-					// consequent commands are independent, so we march on.
-				}
-			}
+	// We have a match! Let's proceed to the consequences...
+	for _, consequentCmd := range hook.consequences {
+		_, err := gribbleEnv.Run(args.apply(consequentCmd))
+		if err != nil {
+			logger.Warning.Printf("When executing the consequences "+
+				"for your '%s' hook in the '%s' group, the command "+
+				"'%s' returned an error: %s",
+				hook.name, hk, consequentCmd, err)
+			// consequent commands are independent, so we march on.
 		}
-	}()
+	}
 }
 
 // gribbleBool translates a value returned by a Gribble command to a boolean
@@ -193,14 +345,60 @@ func readSection(cdata *wini.Data, section string) error {
 		}
 	}
 
-	// Now traverse all of the keys in the section. We'll skip "match" since
-	// we've already grabbed the data. Any other key should correspond to
+	// The options used by the timer, idle and active hook groups.
+	interval, err := readSeconds(cdata, section, "interval")
+	if err != nil {
+		return err
+	}
+	idleTime, err := readSeconds(cdata, section, "idle_time")
+	if err != nil {
+		return err
+	}
+	priority := 0
+	if key := cdata.GetKey(section, "priority"); key != nil {
+		vals, err := key.Ints()
+		if err != nil {
+			return err
+		}
+		priority = vals[len(vals)-1]
+	}
+	synchronous := false
+	if key := cdata.GetKey(section, "synchronous"); key != nil {
+		vals, err := key.Bools()
+		if err != nil {
+			return err
+		}
+		synchronous = vals[len(vals)-1]
+	}
+	var sched *schedule
+	if key := cdata.GetKey(section, "schedule"); key != nil {
+		vals := key.Strings()
+		if sched, err = parseSchedule(vals[len(vals)-1]); err != nil {
+			return fmt.Errorf("The schedule in the '%s' hook could not be "+
+				"parsed: %s", section, err)
+		}
+	}
+
+	// Now traverse all of the keys in the section. We'll skip the options
+	// since we've already grabbed them. Any other key should correspond to
 	// a hook group name.
 	addedOne := false
 	for _, key := range cdata.Keys(section) {
 		groupName := Type(key.Name())
-		if groupName == "match" || groupName == "conjunction" {
+		switch groupName {
+		case "match", "conjunction", "priority", "synchronous",
+			"interval", "schedule", "idle_time":
 			continue
+		case Timer:
+			if interval == 0 && sched == nil {
+				return fmt.Errorf("The '%s' hook needs an 'interval' or a "+
+					"'schedule' to use the '%s' group.", section, groupName)
+			}
+		case Idle, Active:
+			if idleTime == 0 {
+				return fmt.Errorf("The '%s' hook needs an 'idle_time' to use "+
+					"the '%s' group.", section, groupName)
+			}
 		}
 		if _, ok := groups[groupName]; !ok {
 			return fmt.Errorf("Unrecognized hook group '%s' in the '%s' hook.",
@@ -220,6 +418,11 @@ func readSection(cdata *wini.Data, section string) error {
 			satisfies:    satisfies,
 			conjunction:  conjunction,
 			consequences: consequences,
+			priority:     priority,
+			synchronous:  synchronous,
+			interval:     interval,
+			schedule:     sched,
+			idleTime:     idleTime,
 		}
 		groups[groupName] = append(groups[groupName], hook)
 		addedOne = true
@@ -231,6 +434,26 @@ func readSection(cdata *wini.Data, section string) error {
 	return nil
 }
 
+// readSeconds reads the option named name in a hook as a positive number of
+// seconds. If the option isn't set, zero is returned.
+func readSeconds(cdata *wini.Data,
+	section, name string) (time.Duration, error) {
+
+	key := cdata.GetKey(section, name)
+	if key == nil {
+		return 0, nil
+	}
+	vals, err := key.Floats()
+	if err != nil {
+		return 0, err
+	}
+	secs := vals[len(vals)-1]
+	if secs <= 0 {
+		return 0, key.Err("'%s' must be a positive number of seconds.", name)
+	}
+	return time.Duration(secs * float64(time.Second)), nil
+}
+
 // checkCommands runs through a list of strings and tries to parse each as
 // a Gribble command in 'gribbleEnv'. If an error occurs in any of them,
 // the errant command and the error are returned.
@@ -251,8 +474,15 @@ func checkCommands(cmds []string) (string, error) {
 //		Client: "identifier of window being focused",
 //	}
 //	hook.Fire(hook.Focused, args)
+//
+// Client and Head are substituted as they are, since they are numbers. The
+// rest are names, and are substituted as Gribble strings.
 type Args struct {
-	Client string
+	Client    string // ":client:"
+	Workspace string // ":workspace:"
+	Head      string // ":head:"
+	OldLayout string // ":old-layout:"
+	NewLayout string // ":new-layout:"
 }
 
 // apply takes a command string and replaces special strings with values in
@@ -262,9 +492,33 @@ func (args Args) apply(cmd string) string {
 	if len(args.Client) > 0 {
 		replace = append(replace, []string{"\":client:\"", args.Client}...)
 	}
+	if len(args.Workspace) > 0 {
+		replace = append(replace,
+			[]string{"\":workspace:\"", quote(args.Workspace)}...)
+	}
+	if len(args.Head) > 0 {
+		replace = append(replace, []string{"\":head:\"", args.Head}...)
+	}
+	if len(args.OldLayout) > 0 {
+		replace = append(replace,
+			[]string{"\":old-layout:\"", quote(args.OldLayout)}...)
+	}
+	if len(args.NewLayout) > 0 {
+		replace = append(replace,
+			[]string{"\":new-layout:\"", quote(args.NewLayout)}...)
+	}
 
 	if len(replace) == 0 {
 		return cmd
 	}
 	return strings.NewReplacer(replace...).Replace(cmd)
 }
+
+// quote turns s into a Gribble string. Gribble doesn't have escape sequences,
+// so a string with a double quote in it is written between back quotes.
+func quote(s string) string {
+	if strings.Contains(s, "\"") {
+		return "`" + s + "`"
+	}
+	return "\"" + s + "\""
+}
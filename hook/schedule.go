@@ -0,0 +1,128 @@
+package hook
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedule is a cron-like expression with five fields: minute, hour, day of
+// the month, month and day of the week. Each field is a set of allowed
+// values.
+type schedule struct {
+	minutes, hours, days, months, weekdays map[int]bool
+
+	// Whether the day of the month and the day of the week were given as
+	// "*". Like cron, when both are restricted, a day matches if either one
+	// matches.
+	anyDay, anyWeekday bool
+}
+
+// Shortcuts for common schedules.
+var scheduleAliases = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// parseSchedule parses a cron-like expression. Each field may be "*", a
+// number, a range "a-b", a step "*/n", "a-b/n" or "a/n" (which is "a-max/n"),
+// or a comma separated list of any of those.
+func parseSchedule(expr string) (*schedule, error) {
+	if alias, ok := scheduleAliases[strings.TrimSpace(expr)]; ok {
+		expr = alias
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("Expected 5 fields in the schedule '%s' but "+
+			"got %d.", expr, len(fields))
+	}
+
+	s := &schedule{
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+	var err error
+	if s.minutes, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if s.days, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if s.weekdays, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+
+	// Both 0 and 7 are Sunday.
+	if s.weekdays[7] {
+		s.weekdays[0] = true
+	}
+	return s, nil
+}
+
+// parseField returns the set of values in [min, max] allowed by field.
+func parseField(field string, min, max int) (map[int]bool, error) {
+	vals := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rng, step, stepped := part, 1, false
+		if i := strings.Index(part, "/"); i > -1 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("Bad step in '%s'.", part)
+			}
+			rng, step, stepped = part[:i], n, true
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("'%s' is not a number.", bounds[0])
+			}
+			switch {
+			case len(bounds) == 2:
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("'%s' is not a number.", bounds[1])
+				}
+			case !stepped:
+				hi = lo
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("'%s' is not in the range [%d, %d].",
+				part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			vals[v] = true
+		}
+	}
+	return vals, nil
+}
+
+// matches returns true if the minute containing t is in the schedule.
+func (s *schedule) matches(t time.Time) bool {
+	if !s.minutes[t.Minute()] || !s.hours[t.Hour()] ||
+		!s.months[int(t.Month())] {
+
+		return false
+	}
+	day, weekday := s.days[t.Day()], s.weekdays[int(t.Weekday())]
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	}
+	return day || weekday
+}
@@ -0,0 +1,138 @@
+package hook
+
+import (
+	"time"
+
+	"github.com/BurntSushi/gribble"
+
+	"github.com/BurntSushi/wingo/logger"
+)
+
+const (
+	// How often the idle time of the user is checked.
+	idlePollInterval = time.Second
+
+	// The longest wait between checks while getting the idle time fails.
+	idleMaxBackoff = 5 * time.Minute
+)
+
+// idleHook is a hook in the "idle" or "active" group, along with whether the
+// user has been idle for at least its idle time.
+type idleHook struct {
+	hk   Type
+	hook hook
+	idle bool
+}
+
+// StartTimers starts firing the hooks in the "timer", "idle" and "active"
+// groups. idleTime should return how long the user has been idle. If it's
+// nil, the idle and active hooks never fire.
+//
+// Each hook runs as its own job on the main event loop, so StartTimers should
+// be called just before the main event loop starts.
+func StartTimers(idleTime func() (time.Duration, error)) {
+	var scheduled []hook
+	for _, hook := range groups[Timer] {
+		if hook.schedule != nil {
+			scheduled = append(scheduled, hook)
+		}
+		if hook.interval > 0 {
+			go runInterval(hook)
+		}
+	}
+	if len(scheduled) > 0 {
+		go runSchedule(scheduled)
+	}
+
+	var idlers []*idleHook
+	for _, hk := range []Type{Idle, Active} {
+		for _, hook := range groups[hk] {
+			idlers = append(idlers, &idleHook{hk: hk, hook: hook})
+		}
+	}
+	if len(idlers) == 0 {
+		return
+	}
+	if idleTime == nil {
+		logger.Warning.Printf("Idle time is not available, so %d idle and "+
+			"active hooks will never fire.", len(idlers))
+		return
+	}
+	go runIdle(idlers, idleTime)
+}
+
+// runInterval fires a timer hook every interval, forever.
+func runInterval(h hook) {
+	for range time.Tick(h.interval) {
+		fireOne(Timer, h)
+	}
+}
+
+// runSchedule wakes up at the start of every minute and fires each timer hook
+// whose schedule includes that minute. Each minute is checked exactly once,
+// even if firing the hooks takes a while.
+func runSchedule(hooks []hook) {
+	next := time.Now().Truncate(time.Minute)
+	for {
+		next = next.Add(time.Minute)
+		time.Sleep(time.Until(next))
+		for _, h := range hooks {
+			if h.schedule.matches(next) {
+				fireOne(Timer, h)
+			}
+		}
+	}
+}
+
+// runIdle polls the idle time of the user, forever. An idle hook fires once
+// when the user has been idle for its idle time. An active hook fires once
+// when the user does something after being idle for its idle time.
+//
+// While getting the idle time fails, the wait between checks doubles up to
+// idleMaxBackoff, and the failure is only logged once.
+func runIdle(idlers []*idleHook, idleTime func() (time.Duration, error)) {
+	wait := idlePollInterval
+	for {
+		time.Sleep(wait)
+		idle, err := idleTime()
+		if err != nil {
+			if wait == idlePollInterval {
+				logger.Warning.Printf("Could not get the idle time, so idle "+
+					"and active hooks won't fire until it works again: %s",
+					err)
+			}
+			wait *= 2
+			if wait > idleMaxBackoff {
+				wait = idleMaxBackoff
+			}
+			continue
+		}
+		if wait != idlePollInterval {
+			logger.Message.Printf("Getting the idle time works again.")
+			wait = idlePollInterval
+		}
+		for _, ih := range idlers {
+			switch {
+			case !ih.idle && idle >= ih.hook.idleTime:
+				ih.idle = true
+				if ih.hk == Idle {
+					fireOne(ih.hk, ih.hook)
+				}
+			case ih.idle && idle < ih.hook.idleTime:
+				ih.idle = false
+				if ih.hk == Active {
+					fireOne(ih.hk, ih.hook)
+				}
+			}
+		}
+	}
+}
+
+// fireOne runs a single hook in the group hk on the main event loop, and
+// waits for it to finish.
+func fireOne(hk Type, h hook) {
+	exec(func() gribble.Value {
+		fire(hk, group{h}, Args{})
+		return nil
+	})
+}
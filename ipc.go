@@ -2,16 +2,23 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"os"
-	"path"
+	"strings"
+	"text/scanner"
+
+	"github.com/BurntSushi/gribble"
 
 	"github.com/BurntSushi/xgbutil"
 
 	"github.com/BurntSushi/wingo/commands"
+	wingoipc "github.com/BurntSushi/wingo/ipc"
 	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/misc"
+	"github.com/BurntSushi/wingo/wm"
 )
 
 // ipc starts the command server via a unix domain socket. It accepts
@@ -21,55 +28,75 @@ import (
 //
 // Note that every message between the server and client MUST be null
 // terminated.
+//
+// Only processes run by the same user as Wingo may connect. If enabled, a
+// second, read-only socket is also started, which only runs commands that
+// can't change anything.
 func ipc(X *xgbutil.XUtil) {
 	fpath := socketFilePath(X)
+	if wm.Config.ReadOnlySocket {
+		go serveIPC(fpath+wingoipc.ReadOnlySuffix, true)
+	}
+	serveIPC(fpath, false)
+}
 
+func serveIPC(fpath string, readOnly bool) {
 	// Remove the domain socket if it already exists.
 	os.Remove(fpath) // don't care if there's an error
 
 	listener, err := net.Listen("unix", fpath)
 	if err != nil {
-		logger.Error.Fatalln("Could not start IPC listener: %s", err)
+		logger.Error.Fatalf("Could not start IPC listener: %s", err)
 	}
 	defer listener.Close()
 
+	if err := os.Chmod(fpath, 0600); err != nil {
+		logger.Warning.Printf("Could not set permissions of '%s': %s",
+			fpath, err)
+	}
+
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
 			logger.Warning.Printf("Error accepting IPC connection: %s", err)
 			continue
 		}
+		if err := misc.CheckPeer(conn); err != nil {
+			logger.Warning.Printf("Refusing IPC connection: %s", err)
+			conn.Close()
+			continue
+		}
 
 		// Read the command from the connection. All messages are
 		// null-terminated.
-		go handleClient(conn)
+		go handleClient(conn, readOnly)
 	}
 }
 
 func socketFilePath(X *xgbutil.XUtil) string {
-	xc := X.Conn()
-	name := fmt.Sprintf(":%d.%d", xc.DisplayNumber, xc.DefaultScreen)
-
-	var runtimeDir string
-	xdgRuntime := os.Getenv("XDG_RUNTIME_DIR")
-	if len(xdgRuntime) > 0 {
-		runtimeDir = path.Join(xdgRuntime, "wingo")
-	} else {
-		runtimeDir = path.Join(os.TempDir(), "wingo")
+	runtimeDir := wingoipc.SocketDir()
+	if err := os.MkdirAll(runtimeDir, 0700); err != nil {
+		logger.Error.Fatalf("Could not create directory '%s': %s",
+			runtimeDir, err)
 	}
 
-	if err := os.MkdirAll(runtimeDir, 0777); err != nil {
-		logger.Error.Fatalf("Could not create directory '%s': %s",
+	// The directory may have been made by an older Wingo, which let anyone
+	// in.
+	if err := os.Chmod(runtimeDir, 0700); err != nil {
+		logger.Warning.Printf("Could not set permissions of '%s': %s",
 			runtimeDir, err)
 	}
 
-	return path.Join(runtimeDir, name)
+	xc := X.Conn()
+	return wingoipc.SocketFile(xc.DisplayNumber, xc.DefaultScreen)
 }
 
-func handleClient(conn net.Conn) {
+func handleClient(conn net.Conn, readOnly bool) {
 	defer conn.Close()
-	for {
-		reader := bufio.NewReader(conn)
+
+	reader := bufio.NewReader(conn)
+	jsonMode := false
+	for first := true; ; first = false {
 		msg, err := reader.ReadString(0)
 		if err == io.EOF {
 			return
@@ -80,42 +107,154 @@ func handleClient(conn net.Conn) {
 		}
 		msg = msg[:len(msg)-1] // get rid of null terminator
 
-		logger.Lots.Printf("Running command from IPC: '%s'.", msg)
+		if first && msg == wingoipc.Handshake {
+			jsonMode = true
+			fmt.Fprintf(conn, "%s%c", wingoipc.HandshakeReply, 0)
+			continue
+		}
+		if jsonMode {
+			handleJSONRequest(conn, msg, readOnly)
+		} else {
+			handlePlainRequest(conn, msg, readOnly)
+		}
+	}
+}
+
+// handlePlainRequest runs the commands in msg and sends back the return value
+// of the last one as a string. Errors are sent back prefixed with "ERROR: ".
+func handlePlainRequest(conn net.Conn, msg string, readOnly bool) {
+	if err := checkAllowed(msg, readOnly); err != nil {
+		fmt.Fprintf(conn, "ERROR: %s%c", err, 0)
+		return
+	}
 
-		// Run the command. We set the error reporting to verbose. Be kind!
-		// If the command resulted in an error, we stop and send the error back
-		// to the user. (This would be a Gribble parse/type error, not a
-		// Wingo error.)
-		commands.Env.Verbose = true
-		val, err := commands.Env.RunMany(msg)
-		commands.Env.Verbose = false
+	val, err := runIPCCommand(msg)
+	if err != nil {
+		// One command failing doesn't mean we should close the conn.
+		fmt.Fprintf(conn, "ERROR: %s%c", err, 0)
+		return
+	}
+
+	// Send back the return value of the command. If the return value is
+	// nil, send an empty response back.
+	retVal, _, err := ipcValue(val)
+	if err != nil {
+		fmt.Fprintf(conn, "ERROR: %s%c", err, 0)
+		return
+	}
+	switch v := retVal.(type) {
+	case nil:
+		fmt.Fprintf(conn, "%c", 0)
+	case float64:
+		fmt.Fprintf(conn, "%f%c", v, 0)
+	default:
+		fmt.Fprintf(conn, "%v%c", v, 0)
+	}
+}
+
+// handleJSONRequest decodes a JSON request in msg, runs its commands and
+// sends back a JSON response. The protocol is described in the ipc package.
+func handleJSONRequest(conn net.Conn, msg string, readOnly bool) {
+	var req wingoipc.Request
+	var resp wingoipc.Response
+	if err := json.Unmarshal([]byte(msg), &req); err != nil {
+		resp.Error = jsonError("request", err.Error())
+	} else if err := checkAllowed(req.Command, readOnly); err != nil {
+		resp.Id = req.Id
+		resp.Error = jsonError("permission", err.Error())
+	} else {
+		resp.Id = req.Id
+		val, err := runIPCCommand(req.Command)
 		if err != nil {
-			logger.Lots.Printf("ERROR running command: '%s'.", err)
-			fmt.Fprintf(conn, "ERROR: %s%c", err, 0)
+			resp.Error = jsonError("parse", err.Error())
+		} else if retVal, typ, err := ipcValue(val); err != nil {
+			resp.Error = jsonError("internal", err.Error())
+		} else if s, ok := retVal.(string); ok && isCmdError(s) {
+			resp.Error = jsonError("command", s[len("ERROR: "):])
+		} else {
+			resp.Type, resp.Result = typ, retVal
+		}
+	}
+
+	bs, err := json.Marshal(resp)
+	if err != nil {
+		logger.Warning.Printf("Could not encode IPC response: %s", err)
+		return
+	}
+	fmt.Fprintf(conn, "%s%c", bs, 0)
+}
+
+func jsonError(kind, msg string) *wingoipc.Error {
+	return &wingoipc.Error{Kind: kind, Message: msg}
+}
 
-			// One command failing doesn't mean we should close the conn.
+// checkAllowed returns an error if msg runs any command that isn't allowed on
+// the read-only socket, when readOnly is true. Only commands that get
+// information ("Get...") or test conditions ("Match...") are allowed. Since
+// the arguments of a command may be commands themselves, every command in msg
+// is checked before any are run.
+func checkAllowed(msg string, readOnly bool) error {
+	if !readOnly {
+		return nil
+	}
+
+	var s scanner.Scanner
+	s.Init(strings.NewReader(msg))
+	s.Error = func(*scanner.Scanner, string) {} // Gribble reports these
+
+	// Any identifier in a command is the name of a command.
+	for tok := s.Scan(); tok != scanner.EOF; tok = s.Scan() {
+		if tok != scanner.Ident {
 			continue
 		}
+		name := s.TokenText()
+		if !strings.HasPrefix(name, "Get") &&
+			!strings.HasPrefix(name, "Match") {
 
-		// Fetch the return value of the command that was executed, and send
-		// it back to the client. If the return value is nil, send an empty
-		// response back. Otherwise, we need to type switch on all possible
-		// return values.
-		if val != nil {
-			var retVal string
-			switch v := val.(type) {
-			case string:
-				retVal = v
-			case int:
-				retVal = fmt.Sprintf("%d", v)
-			case float64:
-				retVal = fmt.Sprintf("%f", v)
-			default:
-				logger.Error.Fatalf("BUG: Unknown Gribble return type: %T", v)
-			}
-			fmt.Fprintf(conn, "%s%c", retVal, 0)
-		} else {
-			fmt.Fprintf(conn, "%c", 0)
+			return fmt.Errorf("%s is not allowed on the read-only socket", name)
 		}
 	}
+	return nil
+}
+
+// isCmdError returns true if a command returned an error message instead of
+// a value.
+func isCmdError(s string) bool {
+	return strings.HasPrefix(s, "ERROR: ")
+}
+
+// runIPCCommand runs the commands in msg and returns the value of the last
+// one. An error is returned only if the commands couldn't be parsed.
+func runIPCCommand(msg string) (gribble.Value, error) {
+	logger.Lots.Printf("Running command from IPC: '%s'.", msg)
+
+	// Run the command. We set the error reporting to verbose. Be kind!
+	// If the command resulted in an error, we stop and send the error back
+	// to the user. (This would be a Gribble parse/type error, not a
+	// Wingo error.) The commands are run as one job on the main event loop,
+	// so nothing else can happen in between them. A waiting command, like
+	// WaitForManaged, waits in this goroutine instead (see RunMany).
+	val, err := commands.RunMany(msg, true)
+	if err != nil {
+		logger.Lots.Printf("ERROR running command: '%s'.", err)
+	}
+	return val, err
+}
+
+// ipcValue checks that the return value of a command is something that can be
+// sent to an IPC client, and returns it along with the name of its type.
+// A nil value has type "none".
+func ipcValue(val gribble.Value) (interface{}, string, error) {
+	switch v := val.(type) {
+	case nil:
+		return nil, "none", nil
+	case string:
+		return v, "string", nil
+	case int:
+		return v, "int", nil
+	case float64:
+		return v, "float", nil
+	}
+	logger.Warning.Printf("BUG: Unknown Gribble return type: %T", val)
+	return nil, "", fmt.Errorf("unknown return type %T", val)
 }
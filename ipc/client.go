@@ -0,0 +1,231 @@
+package ipc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Any is the type of a command argument that accepts more than one type of
+// value. It may be an int, a float64, a string or a Cmd.
+type Any interface{}
+
+// Cmd is a command whose result is used as the argument of another command.
+type Cmd string
+
+// Client is a connection to Wingo's command socket. It is safe to use from
+// more than one goroutine, although commands are run one at a time.
+type Client struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	nextId int
+}
+
+// Dial connects to the command socket at fpath.
+func Dial(fpath string) (*Client, error) {
+	conn, err := net.Dial("unix", fpath)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{conn: conn, reader: bufio.NewReader(conn)}
+	if err := c.send(Handshake); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if reply, err := c.recv(); err != nil {
+		conn.Close()
+		return nil, err
+	} else if reply != HandshakeReply {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected reply to handshake: %s", reply)
+	}
+	return c, nil
+}
+
+// DialDefault connects to the command socket of the Wingo instance managing
+// the X display in $DISPLAY.
+func DialDefault() (*Client, error) {
+	fpath, err := SocketPath()
+	if err != nil {
+		return nil, err
+	}
+	return Dial(fpath)
+}
+
+// Close closes the connection to Wingo.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Run runs cmd, which may be any number of commands separated by new lines,
+// and returns the result of the last one. If Wingo couldn't run cmd, or the
+// command itself failed, the error is an *Error.
+func (c *Client) Run(cmd string) (Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextId++
+	id := c.nextId
+	req, err := json.Marshal(Request{
+		Id:      json.RawMessage(strconv.Itoa(id)),
+		Command: cmd,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := c.send(string(req)); err != nil {
+		return Result{}, err
+	}
+
+	msg, err := c.recv()
+	if err != nil {
+		return Result{}, err
+	}
+	var resp Response
+	decoder := json.NewDecoder(strings.NewReader(msg))
+	decoder.UseNumber()
+	if err := decoder.Decode(&resp); err != nil {
+		return Result{}, err
+	}
+	if resp.Error != nil {
+		return Result{}, resp.Error
+	}
+	if string(resp.Id) != strconv.Itoa(id) {
+		return Result{}, fmt.Errorf("got response %s to request %d",
+			resp.Id, id)
+	}
+	return newResult(resp.Type, resp.Result)
+}
+
+func (c *Client) send(msg string) error {
+	_, err := fmt.Fprintf(c.conn, "%s%c", msg, 0)
+	return err
+}
+
+func (c *Client) recv() (string, error) {
+	msg, err := c.reader.ReadString(0)
+	if err != nil {
+		return "", err
+	}
+	return msg[:len(msg)-1], nil // get rid of null terminator
+}
+
+// Result is the value returned by a command. Value is nil, or an int,
+// float64 or string, as given by Type.
+type Result struct {
+	Type  string
+	Value interface{}
+}
+
+func newResult(typ string, val interface{}) (Result, error) {
+	r := Result{Type: typ}
+	switch typ {
+	case "none":
+		return r, nil
+	case "string":
+		s, ok := val.(string)
+		if !ok {
+			return r, fmt.Errorf("expected a string but got %T", val)
+		}
+		r.Value = s
+		return r, nil
+	case "int", "float":
+		n, ok := val.(json.Number)
+		if !ok {
+			return r, fmt.Errorf("expected a number but got %T", val)
+		}
+		if typ == "int" {
+			i, err := n.Int64()
+			r.Value = int(i)
+			return r, err
+		}
+		f, err := n.Float64()
+		r.Value = f
+		return r, err
+	}
+	return r, fmt.Errorf("unknown result type '%s'", typ)
+}
+
+// Int returns the result as an int, or 0 if it isn't an int.
+func (r Result) Int() int {
+	n, _ := r.Value.(int)
+	return n
+}
+
+// Float returns the result as a float64, or 0 if it isn't a float.
+func (r Result) Float() float64 {
+	f, _ := r.Value.(float64)
+	return f
+}
+
+// String returns the result as a string. Results that aren't strings are
+// formatted the same way that wingo-cmd prints them.
+func (r Result) String() string {
+	switch v := r.Value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return fmt.Sprintf("%f", v)
+	}
+	return fmt.Sprintf("%v", r.Value)
+}
+
+// Command builds the text of a command from its name and arguments, quoting
+// strings and putting parentheses around any Cmd. Each argument must be an
+// int, a float64, a string or a Cmd.
+func Command(name string, args ...interface{}) (string, error) {
+	pieces := []string{name}
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case int:
+			pieces = append(pieces, strconv.Itoa(arg))
+		case float64:
+			// Wingo only reads a number as a float if it has a decimal point.
+			s := strconv.FormatFloat(arg, 'f', -1, 64)
+			if !strings.Contains(s, ".") {
+				s += ".0"
+			}
+			pieces = append(pieces, s)
+		case string:
+			s, err := quote(arg)
+			if err != nil {
+				return "", err
+			}
+			pieces = append(pieces, s)
+		case Cmd:
+			pieces = append(pieces, "("+string(arg)+")")
+		default:
+			return "", fmt.Errorf("invalid argument %v of type %T to %s",
+				arg, arg, name)
+		}
+	}
+	return strings.Join(pieces, " "), nil
+}
+
+// quote makes s a string literal in Wingo's command language. Wingo doesn't
+// interpret escape sequences, so a raw string is used if s has a quote,
+// backslash or new line in it. A string with both a double quote and a back
+// quote can't be written.
+func quote(s string) (string, error) {
+	if !strings.ContainsAny(s, "\"\\\n") {
+		return `"` + s + `"`, nil
+	}
+	if !strings.Contains(s, "`") {
+		return "`" + s + "`", nil
+	}
+	return "", fmt.Errorf("string %q can't have both \" and ` in it", s)
+}
+
+// run builds a command and runs it. It is used by the generated methods.
+func (c *Client) run(name string, args ...interface{}) (Result, error) {
+	cmd, err := Command(name, args...)
+	if err != nil {
+		return Result{}, err
+	}
+	return c.Run(cmd)
+}
@@ -0,0 +1,1702 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package ipc
+
+// AddWorkspace runs the AddWorkspace command.
+//
+// Adds a new workspace to Wingo with a name Name. Note that a workspace name
+// must be unique with respect to other workspaces and must have non-zero length.
+//
+// The name of the workspace that was added is returned.
+func (c *Client) AddWorkspace(name string) (Result, error) {
+	return c.run("AddWorkspace", name)
+}
+
+// And runs the And command.
+//
+// Returns the logical AND of Op1 and Op2.
+//
+// If Op1 or Op2 is not in {0, 1}, then a warning is logged and nil is returned.
+func (c *Client) And(op1 int, op2 int) (Result, error) {
+	return c.run("And", op1, op2)
+}
+
+// AutoCycle runs the AutoCycle command.
+//
+// Cycles to the next automatic tiling layout in the workspace specified by
+// Workspace.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoCycle(workspace Any) (Result, error) {
+	return c.run("AutoCycle", workspace)
+}
+
+// AutoMakeMaster runs the AutoMakeMaster command.
+//
+// Switches the current window with the first master in the layout for the
+// workspace specified by Workspace.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoMakeMaster(workspace Any) (Result, error) {
+	return c.run("AutoMakeMaster", workspace)
+}
+
+// AutoMaster runs the AutoMaster command.
+//
+// Focuses the (first) master window in the layout for the workspace specified
+// by Workspace.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoMaster(workspace Any) (Result, error) {
+	return c.run("AutoMaster", workspace)
+}
+
+// AutoMastersFewer runs the AutoMastersFewer command.
+//
+// Allows one fewer master window to fit into the master split.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoMastersFewer(workspace Any) (Result, error) {
+	return c.run("AutoMastersFewer", workspace)
+}
+
+// AutoMastersMore runs the AutoMastersMore command.
+//
+// Allows one more master window to fit into the master split.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoMastersMore(workspace Any) (Result, error) {
+	return c.run("AutoMastersMore", workspace)
+}
+
+// AutoNext runs the AutoNext command.
+//
+// Moves focus to the next client in the layout.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoNext(workspace Any) (Result, error) {
+	return c.run("AutoNext", workspace)
+}
+
+// AutoPrev runs the AutoPrev command.
+//
+// Moves focus to the next client in the layout.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoPrev(workspace Any) (Result, error) {
+	return c.run("AutoPrev", workspace)
+}
+
+// AutoResizeMaster runs the AutoResizeMaster command.
+//
+// Increases or decreases the size of the master split by Amount in the layout on
+// the workspace specified by Workspace.
+//
+// Amount should be a ratio between 0.0 and 1.0.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoResizeMaster(workspace Any, amount float64) (Result, error) {
+	return c.run("AutoResizeMaster", workspace, amount)
+}
+
+// AutoResizeWindow runs the AutoResizeWindow command.
+//
+// Increases or decreases the size of the current window by Amount in the layout
+// on the workspace specified by Workspace.
+//
+// Amount should be a ratio between 0.0 and 1.0.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoResizeWindow(workspace Any, amount float64) (Result, error) {
+	return c.run("AutoResizeWindow", workspace, amount)
+}
+
+// AutoSwitchNext runs the AutoSwitchNext command.
+//
+// Switches the current window with the next window in the layout.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoSwitchNext(workspace Any) (Result, error) {
+	return c.run("AutoSwitchNext", workspace)
+}
+
+// AutoSwitchPrev runs the AutoSwitchPrev command.
+//
+// Switches the current window with the previous window in the layout.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoSwitchPrev(workspace Any) (Result, error) {
+	return c.run("AutoSwitchPrev", workspace)
+}
+
+// AutoTile runs the AutoTile command.
+//
+// Initiates automatic tiling on the workspace specified by Workspace. If tiling
+// is already active, the layout will be re-placed.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoTile(workspace Any) (Result, error) {
+	return c.run("AutoTile", workspace)
+}
+
+// AutoUntile runs the AutoUntile command.
+//
+// Stops automatic tiling on the workspace specified by Workspace, and restores
+// windows to their position and geometry before being tiled. If tiling is not
+// active on the specified workspace, this command has no effect.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) AutoUntile(workspace Any) (Result, error) {
+	return c.run("AutoUntile", workspace)
+}
+
+// CloseCmd runs the Close command.
+//
+// Closes the window specified by Client.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) CloseCmd(client Any) (Result, error) {
+	return c.run("Close", client)
+}
+
+// CycleClientChoose runs the CycleClientChoose command.
+//
+// Activates the current choice in a cycle prompt.
+func (c *Client) CycleClientChoose() (Result, error) {
+	return c.run("CycleClientChoose")
+}
+
+// CycleClientHide runs the CycleClientHide command.
+//
+// Hides (i.e., cancels) the current cycle prompt.
+func (c *Client) CycleClientHide() (Result, error) {
+	return c.run("CycleClientHide")
+}
+
+// CycleClientNext runs the CycleClientNext command.
+//
+// Shows the cycle prompt for clients and advances the selection to the next
+// client. If the cycle prompt is already visible, then the selection is advanced
+// to the next client.
+//
+// OnlyActiveWorkspace specifies that only clients on the current workspace should
+// be listed. Valid values are "yes" or "no".
+//
+// OnlyVisible specifies that only clients on visible workspaces should be listed.
+// Valid values are "yes" or "no".
+//
+// ShowIconified specifies that iconified clients will be shown. Valid values are
+// "yes" or "no".
+func (c *Client) CycleClientNext(onlyActiveWorkspace string, onlyVisible string, showIconified string) (Result, error) {
+	return c.run("CycleClientNext", onlyActiveWorkspace, onlyVisible, showIconified)
+}
+
+// CycleClientPrev runs the CycleClientPrev command.
+//
+// Shows the cycle prompt for clients and advances the selection to the previous
+// client. If the cycle prompt is already visible, then the selection is advanced
+// to the previous client.
+//
+// OnlyActiveWorkspace specifies that only clients on the current workspace should
+// be listed. Valid values are "yes" or "no".
+//
+// OnlyVisible specifies that only clients on visible workspaces should be listed.
+// Valid values are "yes" or "no".
+//
+// ShowIconified specifies that iconified clients will be shown. Valid values are
+// "yes" or "no".
+func (c *Client) CycleClientPrev(onlyActiveWorkspace string, onlyVisible string, showIconified string) (Result, error) {
+	return c.run("CycleClientPrev", onlyActiveWorkspace, onlyVisible, showIconified)
+}
+
+// Dale runs the Dale command.
+//
+// Make sure "audio_play_cmd" is set to a program that can play wav files.
+func (c *Client) Dale() (Result, error) {
+	return c.run("Dale")
+}
+
+// Deiconify runs the Deiconify command.
+//
+// Deiconifies (unminimizes) the window specified by Client. If the window
+// is already deiconified, this command has no effect.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Deiconify(client Any) (Result, error) {
+	return c.run("Deiconify", client)
+}
+
+// False runs the False command.
+//
+// Always returns 0.
+func (c *Client) False() (Result, error) {
+	return c.run("False")
+}
+
+// Float runs the Float command.
+//
+// Floats the window specified by Client. If the window is already floating,
+// this command has no effect.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Float(client Any) (Result, error) {
+	return c.run("Float", client)
+}
+
+// Focus runs the Focus command.
+//
+// Focuses the window specified by Client.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Focus(client Any) (Result, error) {
+	return c.run("Focus", client)
+}
+
+// FocusRaise runs the FocusRaise command.
+//
+// Focuses and raises the window specified by Client.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) FocusRaise(client Any) (Result, error) {
+	return c.run("FocusRaise", client)
+}
+
+// FrameBorders runs the FrameBorders command.
+//
+// Set the decorations of the window specified by Client to the "Borders" frame.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) FrameBorders(client Any) (Result, error) {
+	return c.run("FrameBorders", client)
+}
+
+// FrameFull runs the FrameFull command.
+//
+// Set the decorations of the window specified by Client to the "Full" frame.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) FrameFull(client Any) (Result, error) {
+	return c.run("FrameFull", client)
+}
+
+// FrameNada runs the FrameNada command.
+//
+// Set the decorations of the window specified by Client to the "Nada" frame.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) FrameNada(client Any) (Result, error) {
+	return c.run("FrameNada", client)
+}
+
+// FrameSlim runs the FrameSlim command.
+//
+// Set the decorations of the window specified by Client to the "Slim" frame.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) FrameSlim(client Any) (Result, error) {
+	return c.run("FrameSlim", client)
+}
+
+// GapsAdjust runs the GapsAdjust command.
+//
+// Grows or shrinks the gaps used by tiling layouts on the workspace specified by
+// Workspace. Inner and Outer are added to the current inner and outer gaps, and
+// may be negative. Gaps never shrink below zero.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name. If Workspace is ":all:", the gaps are adjusted on every workspace.
+func (c *Client) GapsAdjust(workspace Any, inner int, outer int) (Result, error) {
+	return c.run("GapsAdjust", workspace, inner, outer)
+}
+
+// GapsSet runs the GapsSet command.
+//
+// Sets the gaps used by tiling layouts on the workspace specified by Workspace.
+// Inner is the space in pixels between tiled windows, and Outer is the space in
+// pixels between tiled windows and the edge of the workspace.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name. If Workspace is ":all:", the gaps are set on every workspace.
+func (c *Client) GapsSet(workspace Any, inner int, outer int) (Result, error) {
+	return c.run("GapsSet", workspace, inner, outer)
+}
+
+// GetActive runs the GetActive command.
+//
+// Returns the id of the currently active window. If there is no active window,
+// 0 is returned.
+func (c *Client) GetActive() (Result, error) {
+	return c.run("GetActive")
+}
+
+// GetAllClients runs the GetAllClients command.
+//
+// Returns a list of all client ids separated by new lines. Clients are listed
+// in the order in which they were managed, starting with the oldest client.
+func (c *Client) GetAllClients() (Result, error) {
+	return c.run("GetAllClients")
+}
+
+// GetClientHeight runs the GetClientHeight command.
+//
+// Returns the height of the window specified by Client, including
+// decorations. If the client id is invalid, 0 is returned.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) GetClientHeight(client Any) (Result, error) {
+	return c.run("GetClientHeight", client)
+}
+
+// GetClientList runs the GetClientList command.
+//
+// Returns a list of client ids separated by new lines on the workspace specified
+// by Workspace. Clients are listed in their focus orderering, from most recently
+// focused to least recently focused.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) GetClientList(workspace Any) (Result, error) {
+	return c.run("GetClientList", workspace)
+}
+
+// GetClientName runs the GetClientName command.
+//
+// Returns the name of the window specified by Client active window.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) GetClientName(client Any) (Result, error) {
+	return c.run("GetClientName", client)
+}
+
+// GetClientStatesList runs the GetClientStatesList command.
+//
+// Returns a list of states that the client is in. These states are in
+// correspondence with the possible values of the _NET_WM_STATE property.
+// The following states may appear in the list: STICKY, MAXIMIZED_VERT,
+// MAXIMIZED_HORZ, SKIP_TASKBAR, SKIP_PAGER, HIDDEN, FULLSCREEN,
+// ABOVE, BELOW, DEMANDS_ATTENTION and FOCUSED.
+//
+// More details can be found here: http://goo.gl/FHdjl
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) GetClientStatesList(client Any) (Result, error) {
+	return c.run("GetClientStatesList", client)
+}
+
+// GetClientType runs the GetClientType command.
+//
+// Returns the type of the window specified by Client active window. A window
+// type will either be "desktop", "dock" or "normal".
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) GetClientType(client Any) (Result, error) {
+	return c.run("GetClientType", client)
+}
+
+// GetClientWidth runs the GetClientWidth command.
+//
+// Returns the width of the window specified by Client, including
+// decorations. If the client id is invalid, 0 is returned.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) GetClientWidth(client Any) (Result, error) {
+	return c.run("GetClientWidth", client)
+}
+
+// GetClientWorkspace runs the GetClientWorkspace command.
+//
+// Returns the workspace of the window specified by Client active window.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) GetClientWorkspace(client Any) (Result, error) {
+	return c.run("GetClientWorkspace", client)
+}
+
+// GetClientX runs the GetClientX command.
+//
+// Returns the relative X position of the window specified by Client, where the X
+// position refers to the left-most region of the window, including
+// decorations. Note that "relative" in this case refers to the workspace
+// that the client is on.
+//
+// Relative positions can be used as arguments to MoveRelative.
+//
+// If the client id is invalid, or the client is not visible, -9999 is returned.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) GetClientX(client Any) (Result, error) {
+	return c.run("GetClientX", client)
+}
+
+// GetClientY runs the GetClientY command.
+//
+// Returns the relative Y position of the window specified by Client, where the Y
+// position refers to the left-most region of the window, including
+// decorations. Note that "relative" in this case refers to the workspace
+// that the client is on.
+//
+// Relative positions can be used as arguments to MoveRelative.
+//
+// If the client id is invalid, or the client is not visible, -9999 is returned.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) GetClientY(client Any) (Result, error) {
+	return c.run("GetClientY", client)
+}
+
+// GetHead runs the GetHead command.
+//
+// Returns the index of the current head. Indexing starts at 0. Heads are ordered
+// by their physical position: left to right and then top to bottom.
+func (c *Client) GetHead() (Result, error) {
+	return c.run("GetHead")
+}
+
+// GetHeadHeight runs the GetHeadHeight command.
+//
+// Gets the workable height of the head indexed at Head. If the head specified
+// is not visible, then 0 is returned.
+//
+// Indexing starts at 0. Heads are ordered by their physical position: left to
+// right and then top to bottom.
+func (c *Client) GetHeadHeight(head int) (Result, error) {
+	return c.run("GetHeadHeight", head)
+}
+
+// GetHeadWidth runs the GetHeadWidth command.
+//
+// Gets the workable width of the head indexed at Head. If the head specified
+// is not visible, then 0 is returned.
+//
+// Indexing starts at 0. Heads are ordered by their physical position: left to
+// right and then top to bottom.
+func (c *Client) GetHeadWidth(head int) (Result, error) {
+	return c.run("GetHeadWidth", head)
+}
+
+// GetHeadWorkspace runs the GetHeadWorkspace command.
+//
+// Returns the name of the workspace currently visible on the monitor indexed by
+// Head. Indexing starts at 0. Heads are ordered by their physical position:
+// left to right and then top to bottom.
+func (c *Client) GetHeadWorkspace(head int) (Result, error) {
+	return c.run("GetHeadWorkspace", head)
+}
+
+// GetLayout runs the GetLayout command.
+//
+// Returns the name of the currently active (or "default") layout on the workspace
+// specified by Workspace. Note that when a workspace is set to a tiling layout,
+// it is still possible for clients to be floating.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) GetLayout(workspace Any) (Result, error) {
+	return c.run("GetLayout", workspace)
+}
+
+// GetNumHeads runs the GetNumHeads command.
+//
+// Returns the number of active Heads.
+func (c *Client) GetNumHeads() (Result, error) {
+	return c.run("GetNumHeads")
+}
+
+// GetNumHeadsConnected runs the GetNumHeadsConnected command.
+//
+// Returns the number of Heads connected. This number may be greater
+// than the number returned by GetNumHeads.
+func (c *Client) GetNumHeadsConnected() (Result, error) {
+	return c.run("GetNumHeadsConnected")
+}
+
+// GetTree runs the GetTree command.
+//
+// Returns a JSON document describing the entire state of Wingo: every head,
+// every workspace and every client. It looks like this:
+//
+//	{
+//		"ActiveWorkspace": "1",
+//		"ActiveClient": 1234,
+//		"Heads": [{"Geom": {...}, "Workarea": {...}, "Workspace": "1"}],
+//		"Workspaces": [{"Name": "1", "Layout": "vertical",
+//			"State": "autotiling", "Head": 0, "Clients": [1234]}],
+//		"Clients": [{"Id": 1234, "Name": "...", "Class": "...",
+//			"Instance": "...", "Type": "normal", "Workspace": "1",
+//			"Geom": {...}, "ClientGeom": {...}, "Frame": "full",
+//			"Floating": false, "Maximized": false, "Sticky": false,
+//			"Iconified": false, "Fullscreen": false, "Layer": "default",
+//			"Tags": {"name": "value"}}]
+//	}
+//
+// Each geometry ({...}) has the fields X, Y, Width and Height. Head is -1 for
+// workspaces that aren't visible. Clients are listed in the order in which they
+// were managed, and the clients of a workspace are listed from most recently
+// focused to least recently focused.
+func (c *Client) GetTree() (Result, error) {
+	return c.run("GetTree")
+}
+
+// GetWorkspace runs the GetWorkspace command.
+//
+// Returns the name of the current workspace.
+func (c *Client) GetWorkspace() (Result, error) {
+	return c.run("GetWorkspace")
+}
+
+// GetWorkspaceId runs the GetWorkspaceId command.
+//
+// Returns the id (the index) of the workspace specified by Workspace.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) GetWorkspaceId(workspace Any) (Result, error) {
+	return c.run("GetWorkspaceId", workspace)
+}
+
+// GetWorkspaceList runs the GetWorkspaceList command.
+//
+// Returns a list of all workspaces, in the order that they were added.
+//
+// The special "Sticky" workspace is not included.
+func (c *Client) GetWorkspaceList() (Result, error) {
+	return c.run("GetWorkspaceList")
+}
+
+// GetWorkspaceNext runs the GetWorkspaceNext command.
+//
+// Returns the name of the "next" workspace. The ordering of workspaces is
+// the order in which they were added. This might cause confusing behavior in
+// multi-head setups, since multiple workspaces can be viewable at one time.
+func (c *Client) GetWorkspaceNext() (Result, error) {
+	return c.run("GetWorkspaceNext")
+}
+
+// GetWorkspacePrefix runs the GetWorkspacePrefix command.
+//
+// Returns the first non-visible workspace starting with Prefix. If the current
+// workspace starts with Prefix, then the first workspace *after* the current
+// workspace starting with Prefix will be returned.
+func (c *Client) GetWorkspacePrefix(prefix string) (Result, error) {
+	return c.run("GetWorkspacePrefix", prefix)
+}
+
+// GetWorkspacePrev runs the GetWorkspacePrev command.
+//
+// Returns the name of the "previous" workspace. The ordering of workspaces is
+// the order in which they were added. This might cause confusing behavior in
+// multi-head setups, since multiple workspaces can be viewable at one time.
+func (c *Client) GetWorkspacePrev() (Result, error) {
+	return c.run("GetWorkspacePrev")
+}
+
+// HeadCycle runs the HeadCycle command.
+//
+// Cycles focus to the next head, ordered by index. Heads are ordered
+// by their physical position: left to right and then top to bottom.
+func (c *Client) HeadCycle() (Result, error) {
+	return c.run("HeadCycle")
+}
+
+// HeadFocus runs the HeadFocus command.
+//
+// Focuses the head indexed at Head. Indexing starts at 0. Heads are ordered
+// by their physical position: left to right and then top to bottom.
+func (c *Client) HeadFocus(head int) (Result, error) {
+	return c.run("HeadFocus", head)
+}
+
+// HeadFocusWithClient runs the HeadFocusWithClient command.
+//
+// Focuses the head indexed at Head, and move the Client specified by client to
+// that head. Indexing of heads starts at 0. Heads are ordered by their physical
+// position: left to right and then top to bottom.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) HeadFocusWithClient(head int, client Any) (Result, error) {
+	return c.run("HeadFocusWithClient", head, client)
+}
+
+// HideClientFromPanels runs the HideClientFromPanels command.
+//
+// Sets the appropriate flags so that the window specified by Client is
+// hidden from panels and pagers.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) HideClientFromPanels(client Any) (Result, error) {
+	return c.run("HideClientFromPanels", client)
+}
+
+// Iconify runs the Iconify command.
+//
+// Iconifies (minimizes) the window specified by Client. If the window
+// is already iconified, this command has no effect.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Iconify(client Any) (Result, error) {
+	return c.run("Iconify", client)
+}
+
+// Input runs the Input command.
+//
+// Shows a centered prompt window that allows the user to type in text. If the
+// user presses the Confirm Key (i.e., enter), then the text typed into the
+// input box will be returned.
+//
+// Label will be shown next to the input box.
+//
+// This command may be used as a sub-command to pass user provided arguments to
+// another command.
+func (c *Client) Input(label string) (Result, error) {
+	return c.run("Input", label)
+}
+
+// ManualMoveDown runs the ManualMoveDown command.
+//
+// Moves the current window down in the manual layout on the workspace specified
+// by Workspace. If the window below is part of another container, the current
+// window is moved into that container.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) ManualMoveDown(workspace Any) (Result, error) {
+	return c.run("ManualMoveDown", workspace)
+}
+
+// ManualMoveLeft runs the ManualMoveLeft command.
+//
+// Moves the current window to the left in the manual layout on the workspace
+// specified by Workspace. If the window to the left is part of another
+// container, the current window is moved into that container.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) ManualMoveLeft(workspace Any) (Result, error) {
+	return c.run("ManualMoveLeft", workspace)
+}
+
+// ManualMoveRight runs the ManualMoveRight command.
+//
+// Moves the current window to the right in the manual layout on the workspace
+// specified by Workspace. If the window to the right is part of another
+// container, the current window is moved into that container.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) ManualMoveRight(workspace Any) (Result, error) {
+	return c.run("ManualMoveRight", workspace)
+}
+
+// ManualMoveUp runs the ManualMoveUp command.
+//
+// Moves the current window up in the manual layout on the workspace specified
+// by Workspace. If the window above is part of another container, the current
+// window is moved into that container.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) ManualMoveUp(workspace Any) (Result, error) {
+	return c.run("ManualMoveUp", workspace)
+}
+
+// ManualResize runs the ManualResize command.
+//
+// Increases or decreases the width or height of the current window by Amount in
+// the manual layout on the workspace specified by Workspace.
+//
+// Dimension must be either "Width" or "Height". Amount should be a ratio between
+// 0.0 and 1.0, and is measured with respect to the closest container that can be
+// resized in the given dimension.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) ManualResize(workspace Any, dimension string, amount float64) (Result, error) {
+	return c.run("ManualResize", workspace, dimension, amount)
+}
+
+// ManualSplitHorizontal runs the ManualSplitHorizontal command.
+//
+// Splits the current window horizontally in the manual layout on the workspace
+// specified by Workspace. The next window opened will be placed to the right of
+// the current window.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) ManualSplitHorizontal(workspace Any) (Result, error) {
+	return c.run("ManualSplitHorizontal", workspace)
+}
+
+// ManualSplitVertical runs the ManualSplitVertical command.
+//
+// Splits the current window vertically in the manual layout on the workspace
+// specified by Workspace. The next window opened will be placed below the
+// current window.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) ManualSplitVertical(workspace Any) (Result, error) {
+	return c.run("ManualSplitVertical", workspace)
+}
+
+// ManualTile runs the ManualTile command.
+//
+// Initiates manual tiling on the workspace specified by Workspace. If manual
+// tiling is already active, the layout will be re-placed.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) ManualTile(workspace Any) (Result, error) {
+	return c.run("ManualTile", workspace)
+}
+
+// MatchClientClass runs the MatchClientClass command.
+//
+// Returns 1 if the "class" part of the WM_CLASS property on the window
+// specified by Client contains the substring specified by Class, and otherwise
+// returns 0. The search is done case insensitively.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientClass(client Any, class string) (Result, error) {
+	return c.run("MatchClientClass", client, class)
+}
+
+// MatchClientClassRegex runs the MatchClientClassRegex command.
+//
+// Returns 1 if the "class" part of the WM_CLASS property on the window
+// specified by Client matches the regular expression Pattern, and otherwise
+// returns 0. The match is case sensitive, unless Pattern starts with "(?i)".
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientClassRegex(client Any, pattern string) (Result, error) {
+	return c.run("MatchClientClassRegex", client, pattern)
+}
+
+// MatchClientInstance runs the MatchClientInstance command.
+//
+// Returns 1 if the "instance" part of the WM_CLASS property on the window
+// specified by Client contains the substring specified by Instance, and otherwise
+// returns 0. The search is done case insensitively.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientInstance(client Any, instance string) (Result, error) {
+	return c.run("MatchClientInstance", client, instance)
+}
+
+// MatchClientInstanceRegex runs the MatchClientInstanceRegex command.
+//
+// Returns 1 if the "instance" part of the WM_CLASS property on the window
+// specified by Client matches the regular expression Pattern, and otherwise
+// returns 0. The match is case sensitive, unless Pattern starts with "(?i)".
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientInstanceRegex(client Any, pattern string) (Result, error) {
+	return c.run("MatchClientInstanceRegex", client, pattern)
+}
+
+// MatchClientIsTransient runs the MatchClientIsTransient command.
+//
+// Returns 1 if the window specified by Client is a transient window, and
+// otherwise returns 0. A transient window usually corresponds to some kind of
+// dialog window.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientIsTransient(client Any) (Result, error) {
+	return c.run("MatchClientIsTransient", client)
+}
+
+// MatchClientMachine runs the MatchClientMachine command.
+//
+// Returns 1 if the WM_CLIENT_MACHINE property on the window specified by Client
+// contains the substring specified by Machine, and otherwise returns 0. The
+// search is done case insensitively. WM_CLIENT_MACHINE is the host name of the
+// machine the client is running on.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientMachine(client Any, machine string) (Result, error) {
+	return c.run("MatchClientMachine", client, machine)
+}
+
+// MatchClientMapped runs the MatchClientMapped command.
+//
+// Returns 1 if the window specified by Client is mapped or not.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientMapped(client Any) (Result, error) {
+	return c.run("MatchClientMapped", client)
+}
+
+// MatchClientName runs the MatchClientName command.
+//
+// Returns 1 if the name of the window specified by Client contains the substring
+// specified by Name, and otherwise returns 0. The search is done case
+// insensitively.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientName(client Any, name string) (Result, error) {
+	return c.run("MatchClientName", client, name)
+}
+
+// MatchClientNameRegex runs the MatchClientNameRegex command.
+//
+// Returns 1 if the name of the window specified by Client matches the regular
+// expression Pattern, and otherwise returns 0. The match is case sensitive,
+// unless Pattern starts with "(?i)".
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientNameRegex(client Any, pattern string) (Result, error) {
+	return c.run("MatchClientNameRegex", client, pattern)
+}
+
+// MatchClientPid runs the MatchClientPid command.
+//
+// Returns 1 if the _NET_WM_PID property on the window specified by Client is
+// equal to Pid, and otherwise returns 0.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientPid(client Any, pid int) (Result, error) {
+	return c.run("MatchClientPid", client, pid)
+}
+
+// MatchClientProcess runs the MatchClientProcess command.
+//
+// Returns 1 if the name of the process that owns the window specified by Client
+// contains the substring specified by Name, and otherwise returns 0. The search
+// is done case insensitively.
+//
+// The process is found with the _NET_WM_PID property, and its name is read from
+// /proc. So this only works for clients running on the same machine as Wingo,
+// and on systems with a Linux style /proc.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientProcess(client Any, name string) (Result, error) {
+	return c.run("MatchClientProcess", client, name)
+}
+
+// MatchClientProperty runs the MatchClientProperty command.
+//
+// Returns 1 if the X property named by Property on the window specified by
+// Client has the value Value, and otherwise returns 0. If the property has more
+// than one value (like a list of strings or atoms), then it matches if any of its
+// values is equal to Value.
+//
+// Strings are compared as they are, atoms are compared by name and all other
+// values are compared as decimal integers. For example, this matches windows
+// that asked to be kept above others:
+//
+//	MatchClientProperty ":client:" "_NET_WM_STATE" "_NET_WM_STATE_ABOVE"
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientProperty(client Any, property string, value string) (Result, error) {
+	return c.run("MatchClientProperty", client, property, value)
+}
+
+// MatchClientRole runs the MatchClientRole command.
+//
+// Returns 1 if the WM_WINDOW_ROLE property on the window specified by Client
+// contains the substring specified by Role, and otherwise returns 0. The search
+// is done case insensitively.
+//
+// Applications often use the role to tell apart windows with the same class. For
+// example, the main windows of Firefox have the role "browser".
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientRole(client Any, role string) (Result, error) {
+	return c.run("MatchClientRole", client, role)
+}
+
+// MatchClientRoleRegex runs the MatchClientRoleRegex command.
+//
+// Returns 1 if the WM_WINDOW_ROLE property on the window specified by Client
+// matches the regular expression Pattern, and otherwise returns 0. The match is
+// case sensitive, unless Pattern starts with "(?i)".
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientRoleRegex(client Any, pattern string) (Result, error) {
+	return c.run("MatchClientRoleRegex", client, pattern)
+}
+
+// MatchClientType runs the MatchClientType command.
+//
+// Returns 1 if the type of the window specified by Client matches the type
+// named by Type, and otherwise returns 0.
+//
+// Valid window types are "Normal", "Dock" or "Desktop". See
+// MatchClientWindowType for other types of windows, like dialogs.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientType(client Any, typeArg string) (Result, error) {
+	return c.run("MatchClientType", client, typeArg)
+}
+
+// MatchClientWindowType runs the MatchClientWindowType command.
+//
+// Returns 1 if the window specified by Client has the EWMH window type named by
+// Type, and otherwise returns 0. A window may have more than one type.
+//
+// Type is the name of the type without the "_NET_WM_WINDOW_TYPE_" prefix, like
+// "Normal", "Dialog", "Utility", "Splash", "Toolbar", "Menu", "Dock" or
+// "Desktop". It is matched case insensitively. (Unlike MatchClientType, which
+// only knows about normal, dock and desktop windows.)
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MatchClientWindowType(client Any, typeArg string) (Result, error) {
+	return c.run("MatchClientWindowType", client, typeArg)
+}
+
+// MatchClientWorkspace runs the MatchClientWorkspace command.
+//
+// Returns 1 if the window specified by Client is on the workspace specified by
+// Workspace, and otherwise returns 0. Sticky windows are not on any workspace.
+//
+// Client may be the window id or a substring that matches a window name.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) MatchClientWorkspace(client Any, workspace Any) (Result, error) {
+	return c.run("MatchClientWorkspace", client, workspace)
+}
+
+// Maximize runs the Maximize command.
+//
+// Maximizes the window specified by Client. If the window is already maximized,
+// this command has no effect.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Maximize(client Any) (Result, error) {
+	return c.run("Maximize", client)
+}
+
+// Message runs the Message command.
+//
+// Shows a centered prompt window with the text specified by Text. The message
+// will not disappear until it loses focus or when the confirm or cancel key
+// is pressed.
+func (c *Client) Message(text string) (Result, error) {
+	return c.run("Message", text)
+}
+
+// MouseMove runs the MouseMove command.
+//
+// Initiates a drag that allows a window to be moved with the mouse.
+//
+// This is a special command that can only be assigned in Wingo's mouse
+// configuration file. Invoking this command in any other way has no effect.
+func (c *Client) MouseMove() (Result, error) {
+	return c.run("MouseMove")
+}
+
+// MouseResize runs the MouseResize command.
+//
+// Initiates a drag that allows a window to be resized with the mouse.
+//
+// Direction specifies how the window should be resized, and what the pointer
+// should look like. For example, if Direction is set to "BottomRight", then only
+// the width and height of the window can change---but not the x or y position.
+//
+// Valid values for Direction are: Infer, Top, Bottom, Left, Right, TopLeft,
+// TopRight, BottomLeft and BottomRight. When "Infer" is used, the direction
+// is determined based on where the pointer is on the window when the drag is
+// initiated.
+//
+// This is a special command that can only be assigned in Wingo's mouse
+// configuration file. Invoking this command in any other way has no effect.
+func (c *Client) MouseResize(direction string) (Result, error) {
+	return c.run("MouseResize", direction)
+}
+
+// Move runs the Move command.
+//
+// Moves the window specified by Client to the x and y position specified by
+// X and Y. Note that the origin is located in the top left corner.
+//
+// X and Y may either be pixels (integers) or ratios in the range 0.0 to
+// 1.0 (specifically, (0.0, 1.0]). Ratios are measured with respect to the
+// window's workspace's geometry.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Move(client Any, x Any, y Any) (Result, error) {
+	return c.run("Move", client, x, y)
+}
+
+// MovePointer runs the MovePointer command.
+//
+// Moves the pointer to the x and y position specified by X and Y. Note the the
+// origin is located in the top left corner.
+func (c *Client) MovePointer(x int, y int) (Result, error) {
+	return c.run("MovePointer", x, y)
+}
+
+// MovePointerRelative runs the MovePointerRelative command.
+//
+// Moves the pointer to the x and y position specified by X and Y relative to the
+// current workspace. Note the the origin is located in the top left corner of
+// the current workspace.
+//
+// X and Y may either be pixels (integers) or ratios in the range 0.0 to
+// 1.0 (specifically, (0.0, 1.0]). Ratios are measured with respect to the
+// workspace's geometry.
+func (c *Client) MovePointerRelative(x Any, y Any) (Result, error) {
+	return c.run("MovePointerRelative", x, y)
+}
+
+// MoveRelative runs the MoveRelative command.
+//
+// Moves the window specified by Client to the x and y position specified by
+// X and Y, relative to its workspace. Note that the origin is located in the top
+// left corner of the client's workspace.
+//
+// X and Y may either be pixels (integers) or ratios in the range 0.0 to
+// 1.0 (specifically, (0.0, 1.0]). Ratios are measured with respect to the
+// window's workspace's geometry.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) MoveRelative(client Any, x Any, y Any) (Result, error) {
+	return c.run("MoveRelative", client, x, y)
+}
+
+// Not runs the Not command.
+//
+// Returns the negation of Op. When Op is 0, Not returns 1. When Op is 1, Not
+// returns 0.
+//
+// If Op is not in {0, 1}, then a warning is logged and nil is returned.
+func (c *Client) Not(op int) (Result, error) {
+	return c.run("Not", op)
+}
+
+// Or runs the Or command.
+//
+// Returns the logical OR of Op1 and Op2.
+//
+// If Op1 or Op2 is not in {0, 1}, then a warning is logged and nil is returned.
+func (c *Client) Or(op1 int, op2 int) (Result, error) {
+	return c.run("Or", op1, op2)
+}
+
+// PaperColumnLeft runs the PaperColumnLeft command.
+//
+// Moves the current column one place to the left in the Paper layout on the
+// workspace specified by Workspace.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) PaperColumnLeft(workspace Any) (Result, error) {
+	return c.run("PaperColumnLeft", workspace)
+}
+
+// PaperColumnRight runs the PaperColumnRight command.
+//
+// Moves the current column one place to the right in the Paper layout on the
+// workspace specified by Workspace.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) PaperColumnRight(workspace Any) (Result, error) {
+	return c.run("PaperColumnRight", workspace)
+}
+
+// PaperColumnWidth runs the PaperColumnWidth command.
+//
+// Increases or decreases the width of the current column by Amount in the Paper
+// layout on the workspace specified by Workspace.
+//
+// Amount should be a ratio between 0.0 and 1.0 of the width of the head. A
+// column is never narrower than 0.1 or wider than 1.0.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) PaperColumnWidth(workspace Any, amount float64) (Result, error) {
+	return c.run("PaperColumnWidth", workspace, amount)
+}
+
+// PaperStack runs the PaperStack command.
+//
+// Moves the current window into the column to its left in the Paper layout on
+// the workspace specified by Workspace. The window is placed below the windows
+// already in that column.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) PaperStack(workspace Any) (Result, error) {
+	return c.run("PaperStack", workspace)
+}
+
+// PaperUnstack runs the PaperUnstack command.
+//
+// Moves the current window out of its column and into a new column to the right
+// of it in the Paper layout on the workspace specified by Workspace. This has no
+// effect if the window is already alone in its column.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) PaperUnstack(workspace Any) (Result, error) {
+	return c.run("PaperUnstack", workspace)
+}
+
+// Quit runs the Quit command.
+//
+// Stops Wingo.
+func (c *Client) Quit() (Result, error) {
+	return c.run("Quit")
+}
+
+// Raise runs the Raise command.
+//
+// Raises the window specified by Client to the top of its layer.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Raise(client Any) (Result, error) {
+	return c.run("Raise", client)
+}
+
+// RemoveWorkspace runs the RemoveWorkspace command.
+//
+// Removes the workspace specified by Workspace. Note that a workspace can *only*
+// be removed if it is empty (i.e., does not contain any windows).
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) RemoveWorkspace(workspace Any) (Result, error) {
+	return c.run("RemoveWorkspace", workspace)
+}
+
+// RenameWorkspace runs the RenameWorkspace command.
+//
+// Renames the workspace specified by Workspace to the name in NewName.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+// NewName can only be a string.
+func (c *Client) RenameWorkspace(workspace Any, newName string) (Result, error) {
+	return c.run("RenameWorkspace", workspace, newName)
+}
+
+// Resize runs the Resize command.
+//
+// Resizes the window specified by Client to some width and height specified by
+// Width and Height.
+//
+// Width and Height may either be pixels (integers) or ratios in the range 0.0 to
+// 1.0 (specifically, (0.0, 1.0]). Ratios are measured with respect to the
+// window's workspace's geometry.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Resize(client Any, width Any, height Any) (Result, error) {
+	return c.run("Resize", client, width, height)
+}
+
+// Restart runs the Restart command.
+//
+// Restarts Wingo in place using exec. This should be used to reload Wingo
+// after you've made changes to its configuration.
+func (c *Client) Restart() (Result, error) {
+	return c.run("Restart")
+}
+
+// Script runs the Script command.
+//
+// Executes a script in $XDG_CONFIG_HOME/wingo/scripts. The command
+// may include arguments.
+func (c *Client) Script(command string) (Result, error) {
+	return c.run("Script", command)
+}
+
+// ScriptConfig runs the ScriptConfig command.
+//
+// Returns the path to a script's configuration file.
+func (c *Client) ScriptConfig(scriptName string) (Result, error) {
+	return c.run("ScriptConfig", scriptName)
+}
+
+// SelectClient runs the SelectClient command.
+//
+// Shows a centered prompt window with a list of clients satisfying the arguments
+// provided.
+//
+// OnlyActiveWorkspace specifies that only clients on the current workspace should
+// be listed. Valid values are "yes" or "no".
+//
+// OnlyVisible specifies that only clients on visible workspaces should be listed.
+// Valid values are "yes" or "no".
+//
+// ShowIconified specifies that iconified clients will be shown. Valid values are
+// "yes" or "no".
+//
+// TabCompletetion can be set to either "Prefix", "Any" or "Multiple". When it's
+// set to "Prefix", the clients can be searched by a prefix matching string. When
+// it's set to "Any", the clients can be searched by a substring matching string.
+// When it's set to "Multiple", the clients can be searched by multiple space-
+// separated substring matching strings.
+//
+// This command may be used as a sub-command to pass a particular client to
+// another command.
+func (c *Client) SelectClient(tabCompletion string, onlyActiveWorkspace string, onlyVisible string, showIconified string) (Result, error) {
+	return c.run("SelectClient", tabCompletion, onlyActiveWorkspace, onlyVisible, showIconified)
+}
+
+// SelectWorkspace runs the SelectWorkspace command.
+//
+// Shows a centered prompt window with a list of all workspaces.
+//
+// TabCompletetion can be set to either "Prefix", "Any" or "Multiple". When it's
+// set to "Prefix", the clients can be searched by a prefix matching string. When
+// it's set to "Any", the clients can be searched by a substring matching string.
+// When it's set to "Multiple", the clients can be searched by multiple space-
+// separated substring matching strings.
+//
+// This command may be used as a sub-command to pass a particular workspace to
+// another command.
+func (c *Client) SelectWorkspace(tabCompletion string) (Result, error) {
+	return c.run("SelectWorkspace", tabCompletion)
+}
+
+// SetLayout runs the SetLayout command.
+//
+// Sets the current layout of the workspace specified by Workspace to the layout
+// named by Name. If a layout with name Name does not exist, this command has
+// no effect.
+//
+// Note that this command has no effect if the workspace is not visible.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) SetLayout(workspace Any, name string) (Result, error) {
+	return c.run("SetLayout", workspace, name)
+}
+
+// SetOpacity runs the SetOpacity command.
+//
+// Sets the opacity of the window specified by Client to the opacity level
+// specified by Opacity.
+//
+// This command won't have any effect unless you're running a compositing manager
+// like compton or cairo-compmgr.
+//
+// Client may be the window id or a substring that matches a window name.
+//
+// Opacity should be a float in the range 0.0 to 1.0, inclusive, where 0.0 is
+// completely transparent and 1.0 is completely opaque.
+func (c *Client) SetOpacity(client Any, opacity float64) (Result, error) {
+	return c.run("SetOpacity", client, opacity)
+}
+
+// SetPlacement runs the SetPlacement command.
+//
+// Sets the policy used to place the window specified by Client in the floating
+// layout, overriding the floating_placement option for that window only. If the
+// window is floating on a visible workspace, it is placed again right away. This
+// is most useful in a "managed" hook, to place particular windows differently.
+//
+// Client may be the window id or a substring that matches a window name.
+//
+// Policy must be one of "smart", "cascade", "centered", "under-pointer",
+// "transient-parent" or "random".
+func (c *Client) SetPlacement(client Any, policy string) (Result, error) {
+	return c.run("SetPlacement", client, policy)
+}
+
+// Shell runs the Shell command.
+//
+// Attempts to execute the shell command specified by Command. If an error occurs,
+// it will be logged to Wingo's stderr.
+func (c *Client) Shell(command string) (Result, error) {
+	return c.run("Shell", command)
+}
+
+// ShowClientInPanels runs the ShowClientInPanels command.
+//
+// Sets the appropriate flags so that the window specified by Client is
+// shown on panels and pagers.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) ShowClientInPanels(client Any) (Result, error) {
+	return c.run("ShowClientInPanels", client)
+}
+
+// SnapBottomLeft runs the SnapBottomLeft command.
+//
+// Makes the floating window specified by Client fill the bottom-left quarter of
+// its head. Running this command again on the same window cycles its width
+// through 1/2, 1/3 and 2/3 of the head.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) SnapBottomLeft(client Any) (Result, error) {
+	return c.run("SnapBottomLeft", client)
+}
+
+// SnapBottomRight runs the SnapBottomRight command.
+//
+// Makes the floating window specified by Client fill the bottom-right quarter of
+// its head. Running this command again on the same window cycles its width
+// through 1/2, 1/3 and 2/3 of the head.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) SnapBottomRight(client Any) (Result, error) {
+	return c.run("SnapBottomRight", client)
+}
+
+// SnapLeft runs the SnapLeft command.
+//
+// Makes the floating window specified by Client fill the left half of its head.
+// Running this command again on the same window cycles its width through 1/2,
+// 1/3 and 2/3 of the head.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) SnapLeft(client Any) (Result, error) {
+	return c.run("SnapLeft", client)
+}
+
+// SnapRight runs the SnapRight command.
+//
+// Makes the floating window specified by Client fill the right half of its head.
+// Running this command again on the same window cycles its width through 1/2,
+// 1/3 and 2/3 of the head.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) SnapRight(client Any) (Result, error) {
+	return c.run("SnapRight", client)
+}
+
+// SnapTopLeft runs the SnapTopLeft command.
+//
+// Makes the floating window specified by Client fill the top-left quarter of its
+// head. Running this command again on the same window cycles its width through
+// 1/2, 1/3 and 2/3 of the head.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) SnapTopLeft(client Any) (Result, error) {
+	return c.run("SnapTopLeft", client)
+}
+
+// SnapTopRight runs the SnapTopRight command.
+//
+// Makes the floating window specified by Client fill the top-right quarter of
+// its head. Running this command again on the same window cycles its width
+// through 1/2, 1/3 and 2/3 of the head.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) SnapTopRight(client Any) (Result, error) {
+	return c.run("SnapTopRight", client)
+}
+
+// StopHook runs the StopHook command.
+//
+// Stops the hook group being fired once the current hook has finished. Hooks
+// later in the group, including those with a lower priority, are not run.
+//
+// This command only makes sense in the commands of a hook in hooks.wini. It has
+// no effect anywhere else.
+func (c *Client) StopHook() (Result, error) {
+	return c.run("StopHook")
+}
+
+// TagGet runs the TagGet command.
+//
+// Retrieves the tag with name Name for the client specified by Client.
+//
+// Client may be the window id or a substring that matches a window name.
+// Or, it may be zero and the property will be retrieved from the root
+// window.
+//
+// Tag names may only contain the following characters: [-a-zA-Z0-9_].
+func (c *Client) TagGet(client Any, name string) (Result, error) {
+	return c.run("TagGet", client, name)
+}
+
+// TagSet runs the TagSet command.
+//
+// Sets the tag with name Name to value Value for the client specified by Client.
+//
+// Client may be the window id or a substring that matches a window name.
+// Or, it may be zero and the property will be set on the root window.
+//
+// Tag names may only contain the following characters: [-a-zA-Z0-9_].
+func (c *Client) TagSet(client Any, name string, value string) (Result, error) {
+	return c.run("TagSet", client, name, value)
+}
+
+// ToggleFloating runs the ToggleFloating command.
+//
+// Toggles whether the window specified by Client should be forced into the
+// floating layout. A window forced into the floating layout CANNOT be tiled.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) ToggleFloating(client Any) (Result, error) {
+	return c.run("ToggleFloating", client)
+}
+
+// ToggleIconify runs the ToggleIconify command.
+//
+// Iconifies (minimizes) or deiconifies (unminimizes) the window specified by
+// Client.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) ToggleIconify(client Any) (Result, error) {
+	return c.run("ToggleIconify", client)
+}
+
+// ToggleMaximize runs the ToggleMaximize command.
+//
+// Maximizes or restores the window specified by Client.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) ToggleMaximize(client Any) (Result, error) {
+	return c.run("ToggleMaximize", client)
+}
+
+// ToggleStackAbove runs the ToggleStackAbove command.
+//
+// Toggles the layer of the window specified by Client from normal to above. When
+// a window is in the "above" layer, it will always be above other (normal)
+// clients.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) ToggleStackAbove(client Any) (Result, error) {
+	return c.run("ToggleStackAbove", client)
+}
+
+// ToggleStackBelow runs the ToggleStackBelow command.
+//
+// Toggles the layer of the window specified by Client from normal to below. When
+// a window is in the "below" layer, it will always be below other (normal)
+// clients.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) ToggleStackBelow(client Any) (Result, error) {
+	return c.run("ToggleStackBelow", client)
+}
+
+// ToggleSticky runs the ToggleSticky command.
+//
+// Toggles the sticky status of the window specified by Client. When a window is
+// sticky, it will always be visible unless iconified. (i.e., it does not belong
+// to any particular workspace.)
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) ToggleSticky(client Any) (Result, error) {
+	return c.run("ToggleSticky", client)
+}
+
+// True runs the True command.
+//
+// Always returns 1.
+func (c *Client) True() (Result, error) {
+	return c.run("True")
+}
+
+// Unfloat runs the Unfloat command.
+//
+// Unfloats the window specified by Client. If the window is not floating,
+// this command has no effect.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Unfloat(client Any) (Result, error) {
+	return c.run("Unfloat", client)
+}
+
+// Unmaximize runs the Unmaximize command.
+//
+// Unmaximizes the window specified by Client. If the window is not maximized,
+// this command has no effect.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) Unmaximize(client Any) (Result, error) {
+	return c.run("Unmaximize", client)
+}
+
+// WaitForFocused runs the WaitForFocused command.
+//
+// Blocks until the active client changes to a client for which the command
+// Match returns 1, and returns the id of that client. The special string
+// ":client:" in Match is replaced with the id of each newly focused client.
+// Use "True" for Match to wait for any change of the active client.
+//
+// If Timeout is greater than 0 and no client has matched after Timeout
+// milliseconds, an error is returned.
+//
+// Since this command blocks, it can only be run on its own (not as an argument
+// of another command), like with wingo-cmd. It can't be used by hooks.
+func (c *Client) WaitForFocused(match string, timeout int) (Result, error) {
+	return c.run("WaitForFocused", match, timeout)
+}
+
+// WaitForManaged runs the WaitForManaged command.
+//
+// Blocks until a new client is managed for which the command Match returns 1,
+// and returns the id of that client. The special string ":client:" in Match is
+// replaced with the id of each new client, just like in hooks. Match can be
+// written between back quotes so that it may contain double quotes, as in
+// MatchClientClass ":client:" "Firefox".
+//
+// Only clients managed after this command starts are considered, so it should
+// be started before the program that creates the client.
+//
+// If Timeout is greater than 0 and no client has matched after Timeout
+// milliseconds, an error is returned.
+//
+// Since this command blocks, it can only be run on its own (not as an argument
+// of another command), like with wingo-cmd. It can't be used by hooks.
+func (c *Client) WaitForManaged(match string, timeout int) (Result, error) {
+	return c.run("WaitForManaged", match, timeout)
+}
+
+// WaitForVisible runs the WaitForVisible command.
+//
+// Blocks until the workspace specified by Workspace is visible on some head,
+// and returns the name of the workspace. If the workspace is already visible,
+// this command returns immediately.
+//
+// If Timeout is greater than 0 and the workspace isn't visible after Timeout
+// milliseconds, an error is returned.
+//
+// Since this command blocks, it can only be run on its own (not as an argument
+// of another command), like with wingo-cmd. It can't be used by hooks.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) WaitForVisible(workspace Any, timeout int) (Result, error) {
+	return c.run("WaitForVisible", workspace, timeout)
+}
+
+// WingoExec runs the WingoExec command.
+//
+// Executes a series of Wingo commands specified by Commands. If an error occurs
+// while executing the command, it will be shown in a popup message.
+func (c *Client) WingoExec(commands string) (Result, error) {
+	return c.run("WingoExec", commands)
+}
+
+// WingoHelp runs the WingoHelp command.
+//
+// Shows the usage information for a particular command specified by CommandName.
+func (c *Client) WingoHelp(commandName string) (Result, error) {
+	return c.run("WingoHelp", commandName)
+}
+
+// Workspace runs the Workspace command.
+//
+// Sets the current workspace to the one specified by Workspace.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) Workspace(workspace Any) (Result, error) {
+	return c.run("Workspace", workspace)
+}
+
+// WorkspaceGreedy runs the WorkspaceGreedy command.
+//
+// Sets the current workspace to the one specified by Workspace in a greedy
+// fashion.
+//
+// A greedy switch *always* brings the specified workspace to the
+// currently focused head. (N.B. Greedy is only different when switching between
+// two visible workspaces.)
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) WorkspaceGreedy(workspace Any) (Result, error) {
+	return c.run("WorkspaceGreedy", workspace)
+}
+
+// WorkspaceGreedyWithClient runs the WorkspaceGreedyWithClient command.
+//
+// Sets the current workspace to the workspace specified by Workspace in a greedy
+// fashion, and moves the window specified by Client to that workspace.
+//
+// A greedy switch *always* brings the specified workspace to the
+// currently focused head. (N.B. Greedy is only different when switching between
+// two visible workspaces.)
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) WorkspaceGreedyWithClient(workspace Any, client Any) (Result, error) {
+	return c.run("WorkspaceGreedyWithClient", workspace, client)
+}
+
+// WorkspaceHead runs the WorkspaceHead command.
+//
+// Retrieves the head index of the workspace specified by Workspace. If the
+// workspace is not visible, then -1 is returned.
+//
+// Head indexing starts at 0. Heads are ordered by their physical position: left
+// to right and then top to bottom.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+func (c *Client) WorkspaceHead(workspace Any) (Result, error) {
+	return c.run("WorkspaceHead", workspace)
+}
+
+// WorkspaceSendClient runs the WorkspaceSendClient command.
+//
+// Sends the window specified by Client to the workspace specified by Workspace.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) WorkspaceSendClient(workspace Any, client Any) (Result, error) {
+	return c.run("WorkspaceSendClient", workspace, client)
+}
+
+// WorkspaceToHead runs the WorkspaceToHead command.
+//
+// Sets the workspace specified by Workspace to appear on the head specified by
+// the Head index.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+//
+// Head indexing starts at 0. Heads are ordered by their physical position: left
+// to right and then top to bottom.
+func (c *Client) WorkspaceToHead(head int, workspace Any) (Result, error) {
+	return c.run("WorkspaceToHead", head, workspace)
+}
+
+// WorkspaceWithClient runs the WorkspaceWithClient command.
+//
+// Sets the current workspace to the workspace specified by Workspace, and moves
+// the window specified by Client to that workspace.
+//
+// Workspace may be a workspace index (integer) starting at 0, or a workspace
+// name.
+//
+// Client may be the window id or a substring that matches a window name.
+func (c *Client) WorkspaceWithClient(workspace Any, client Any) (Result, error) {
+	return c.run("WorkspaceWithClient", workspace, client)
+}
@@ -0,0 +1,53 @@
+/*
+Package ipc is a client for Wingo's command and event sockets.
+
+A Client sends commands to Wingo and returns their results. Every command
+registered with Wingo has a method on Client, generated from the definitions
+in the commands package, so that commands don't have to be built by hand:
+
+	c, err := ipc.DialDefault()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+
+	active, err := c.GetActive()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := c.Maximize(active.Int()); err != nil {
+		log.Fatal(err)
+	}
+
+A Cmd can be given as an argument of type Any to run a command and use its
+result, like parentheses do in Wingo's command language. This sends the
+active client to the workspace named "2":
+
+	c.WorkspaceSendClient("2", ipc.Cmd("GetActive"))
+
+Commands may also be run as strings with Client.Run. The Command function
+builds such a string from a command name and its arguments.
+
+Events are read from Wingo's event socket with an Events value, which decodes
+every event into one of the types in the event package:
+
+	evs, err := ipc.SubscribeDefault()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for {
+		ev, err := evs.Next()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if focused, ok := ev.Data.(event.FocusedClient); ok {
+			fmt.Println(focused.Name)
+		}
+	}
+
+The methods in commands.go are generated by running "go generate" in this
+directory whenever a command is added or changed.
+*/
+package ipc
+
+//go:generate go run gen.go
@@ -0,0 +1,154 @@
+package ipc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/wingo/event"
+)
+
+// Event is an event read from Wingo's event socket. Data is a value of the
+// type in the event package with the same name as the event, such as
+// event.FocusedClient. If the name of the event isn't known, Data is a
+// map[string]interface{} of its fields.
+type Event struct {
+	Name string
+	Seq  uint64
+	Data event.Event
+}
+
+// eventTypes maps the name of every event that Wingo sends to its type.
+var eventTypes = make(map[string]reflect.Type)
+
+func init() {
+	for _, ev := range []event.Event{
+		event.Noop{},
+		event.Restarting{},
+		event.Subscribed{},
+		event.Dropped{},
+		event.Snapshot{},
+		event.ChangedWorkspace{},
+		event.ChangedVisibleWorkspace{},
+		event.ChangedWorkspaceNames{},
+		event.AddedWorkspace{},
+		event.RemovedWorkspace{},
+		event.ChangedHeads{},
+		event.FocusedClient{},
+		event.UnfocusedClient{},
+		event.MappedClient{},
+		event.UnmappedClient{},
+		event.ManagedClient{},
+		event.UnmanagedClient{},
+		event.ChangedClientName{},
+		event.ChangedActiveClient{},
+		event.ChangedClientGeometry{},
+		event.FloatedClient{},
+		event.UnfloatedClient{},
+		event.MaximizedClient{},
+		event.UnmaximizedClient{},
+		event.FullscreenedClient{},
+		event.UnfullscreenedClient{},
+		event.ChangedClientUrgency{},
+		event.ChangedLayout{},
+	} {
+		t := reflect.TypeOf(ev)
+		eventTypes[t.Name()] = t
+	}
+}
+
+// Events is a connection to Wingo's event socket.
+type Events struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// Subscribe connects to the event socket at fpath. Note that fpath is the
+// path of the event socket, which is the path of the command socket followed
+// by NotifySuffix.
+func Subscribe(fpath string) (*Events, error) {
+	conn, err := net.Dial("unix", fpath)
+	if err != nil {
+		return nil, err
+	}
+	return &Events{conn, bufio.NewReader(conn)}, nil
+}
+
+// SubscribeDefault connects to the event socket of the Wingo instance
+// managing the X display in $DISPLAY.
+func SubscribeDefault() (*Events, error) {
+	fpath, err := SocketPath()
+	if err != nil {
+		return nil, err
+	}
+	return Subscribe(fpath + NotifySuffix)
+}
+
+// Close closes the connection to Wingo.
+func (evs *Events) Close() error {
+	return evs.conn.Close()
+}
+
+// Next waits for the next event and returns it. This includes the Subscribed
+// event that Wingo sends when the connection is made or a filter is set, and
+// the Noop event that is sent every few seconds when nothing happens.
+func (evs *Events) Next() (Event, error) {
+	msg, err := evs.reader.ReadString(0)
+	if err != nil {
+		return Event{}, err
+	}
+	msg = msg[:len(msg)-1] // get rid of null terminator
+
+	var header struct {
+		EventName string
+		Seq       uint64
+	}
+	if err := json.Unmarshal([]byte(msg), &header); err != nil {
+		return Event{}, err
+	}
+	ev := Event{Name: header.EventName, Seq: header.Seq}
+
+	t, ok := eventTypes[ev.Name]
+	if !ok {
+		fields := make(map[string]interface{})
+		decoder := json.NewDecoder(strings.NewReader(msg))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fields); err != nil {
+			return Event{}, err
+		}
+		ev.Data = fields
+		return ev, nil
+	}
+
+	// The fields of embedded structs (like event.Client) are sent as fields
+	// of the event itself, which is exactly how they are decoded.
+	data := reflect.New(t)
+	if err := json.Unmarshal([]byte(msg), data.Interface()); err != nil {
+		return Event{}, fmt.Errorf("could not decode %s: %s", ev.Name, err)
+	}
+	ev.Data = data.Elem().Interface()
+	return ev, nil
+}
+
+// SetFilter tells Wingo to only send the events that match filter. Wingo
+// sends a Subscribed event once the filter is in effect.
+func (evs *Events) SetFilter(filter event.Filter) error {
+	bs, err := json.Marshal(filter)
+	if err != nil {
+		return err
+	}
+	return evs.send(bs)
+}
+
+// RequestSnapshot asks Wingo to send an event.Snapshot of its current state.
+func (evs *Events) RequestSnapshot() error {
+	return evs.send([]byte(`{"Snapshot": true}`))
+}
+
+func (evs *Events) send(msg []byte) error {
+	_, err := fmt.Fprintf(evs.conn, "%s%c", msg, 0)
+	return err
+}
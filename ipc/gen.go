@@ -0,0 +1,225 @@
+//go:build ignore
+
+// gen.go writes commands.go, which has a method on Client for every command
+// registered in the commands package. Commands are found by reading the
+// source of the commands package, rather than by importing it, since
+// importing it loads Wingo's data files.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// reserved are the names of methods on Client that aren't commands. A
+// command with one of these names gets a method with "Cmd" added to its name.
+var reserved = map[string]bool{
+	"Close": true,
+	"Run":   true,
+}
+
+type command struct {
+	method string
+	name   string
+	help   string
+	params []param
+}
+
+type param struct {
+	name string
+	typ  string
+	num  int
+}
+
+func main() {
+	log.SetFlags(0)
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "../commands", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkg, ok := pkgs["commands"]
+	if !ok {
+		log.Fatal("Could not find the commands package.")
+	}
+
+	structs := make(map[string]*ast.StructType)
+	var registered []string
+	for _, file := range pkg.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				if st, ok := n.Type.(*ast.StructType); ok {
+					structs[n.Name.Name] = st
+				}
+			case *ast.ValueSpec:
+				if len(n.Names) == 1 && n.Names[0].Name == "Env" {
+					registered = registeredCommands(n)
+				}
+			}
+			return true
+		})
+	}
+	if len(registered) == 0 {
+		log.Fatal("Could not find any commands registered in Env.")
+	}
+
+	cmds := make([]command, 0, len(registered))
+	for _, name := range registered {
+		st, ok := structs[name]
+		if !ok {
+			log.Fatalf("Could not find the definition of %s.", name)
+		}
+		cmds = append(cmds, newCommand(name, st))
+	}
+	sort.Slice(cmds, func(i, j int) bool {
+		return cmds[i].method < cmds[j].method
+	})
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintln(buf, "// Code generated by gen.go; DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package ipc")
+	for _, cmd := range cmds {
+		writeMethod(buf, cmd)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("Could not format generated code: %s", err)
+	}
+	if err := ioutil.WriteFile("commands.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// registeredCommands returns the names of the command types in the list
+// given to gribble.New.
+func registeredCommands(spec *ast.ValueSpec) []string {
+	var names []string
+	ast.Inspect(spec, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		if ident, ok := lit.Type.(*ast.Ident); ok {
+			names = append(names, ident.Name)
+		}
+		return true
+	})
+	return names
+}
+
+func newCommand(name string, st *ast.StructType) command {
+	cmd := command{method: name, name: name}
+	if reserved[name] {
+		cmd.method = name + "Cmd"
+	}
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) != 1 {
+			continue
+		}
+		tagStr, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			log.Fatalf("Bad struct tag in %s: %s", name, err)
+		}
+		tag := reflect.StructTag(tagStr)
+		fieldName := field.Names[0].Name
+
+		if fieldName == "Help" {
+			cmd.help = strings.TrimSpace(tagStr)
+			continue
+		}
+		numStr := tag.Get("param")
+		if len(numStr) == 0 {
+			continue
+		}
+		num, err := strconv.Atoi(numStr)
+		if err != nil {
+			log.Fatalf("Bad param number for %s.%s: %s", name, fieldName, err)
+		}
+		cmd.params = append(cmd.params, param{
+			name: paramName(fieldName),
+			typ:  paramType(name, fieldName, field.Type),
+			num:  num,
+		})
+	}
+	sort.Slice(cmd.params, func(i, j int) bool {
+		return cmd.params[i].num < cmd.params[j].num
+	})
+	return cmd
+}
+
+// paramName turns the name of a struct field into the name of a method
+// argument.
+func paramName(field string) string {
+	runes := []rune(field)
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		// Lower case an acronym at the start, like "X" or "ID".
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	name := string(runes)
+	if token.Lookup(name).IsKeyword() {
+		name += "Arg"
+	}
+	return name
+}
+
+// paramType returns the Go type of a method argument for a command parameter.
+// Parameters that accept more than one type become Any.
+func paramType(cmd, field string, typ ast.Expr) string {
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		switch typ.Name {
+		case "int", "float64", "string":
+			return typ.Name
+		}
+	case *ast.SelectorExpr:
+		if typ.Sel.Name == "Any" {
+			return "Any"
+		}
+	}
+	log.Fatalf("Unsupported type for %s.%s.", cmd, field)
+	panic("unreachable")
+}
+
+func writeMethod(buf *bytes.Buffer, cmd command) {
+	fmt.Fprintln(buf)
+	fmt.Fprintf(buf, "// %s runs the %s command.\n", cmd.method, cmd.name)
+	if len(cmd.help) > 0 {
+		fmt.Fprintln(buf, "//")
+		for _, line := range strings.Split(cmd.help, "\n") {
+			fmt.Fprintln(buf, strings.TrimSpace("// "+line))
+		}
+	}
+
+	var params, args []string
+	for _, p := range cmd.params {
+		params = append(params, fmt.Sprintf("%s %s", p.name, p.typ))
+		args = append(args, p.name)
+	}
+	fmt.Fprintf(buf, "func (c *Client) %s(%s) (Result, error) {\n",
+		cmd.method, strings.Join(params, ", "))
+	if len(args) == 0 {
+		fmt.Fprintf(buf, "\treturn c.run(%q)\n", cmd.name)
+	} else {
+		fmt.Fprintf(buf, "\treturn c.run(%q, %s)\n",
+			cmd.name, strings.Join(args, ", "))
+	}
+	fmt.Fprintln(buf, "}")
+}
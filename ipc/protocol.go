@@ -0,0 +1,58 @@
+package ipc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Handshake is the message that a client sends as the first message on a
+// connection to the command socket to switch that connection to the JSON
+// protocol. Wingo replies with HandshakeReply.
+//
+// In the JSON protocol, every request is a JSON object like
+//
+//	{"id": 1, "command": "GetActive"}
+//
+// and every response is a JSON object with the same id and either a result
+// or an error:
+//
+//	{"id": 1, "type": "int", "result": 12345}
+//	{"id": 2, "error": {"kind": "parse", "message": "..."}}
+//
+// The id may be any JSON value, and is only there so that a client can match
+// responses to requests. The type of a result is one of "string", "int",
+// "float" or "none". The kind of an error is one of "request" (the request
+// isn't valid JSON), "parse" (the command couldn't be parsed), "command" (the
+// command ran but reported an error), "permission" (the command isn't allowed
+// on the read-only socket) or "internal" (the command returned a value that
+// Wingo doesn't know how to send).
+//
+// As with the plain protocol, every message must be null terminated.
+const (
+	Handshake      = "PROTOCOL json"
+	HandshakeReply = `{"protocol":"json"}`
+)
+
+// Request is a request in the JSON protocol.
+type Request struct {
+	Id      json.RawMessage `json:"id"`
+	Command string          `json:"command"`
+}
+
+// Response is a response in the JSON protocol.
+type Response struct {
+	Id     json.RawMessage `json:"id"`
+	Type   string          `json:"type,omitempty"`
+	Result interface{}     `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// Error is an error in the JSON protocol.
+type Error struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%s error: %s", err.Kind, err.Message)
+}
@@ -0,0 +1,69 @@
+package ipc
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// NotifySuffix is added to the path of the command socket to get the
+	// path of the event socket.
+	NotifySuffix = "-notify"
+
+	// ReadOnlySuffix is added to the path of the command socket to get the
+	// path of the read-only command socket, which only runs commands whose
+	// names start with "Get" or "Match". It only exists if the
+	// "read_only_socket" option is enabled.
+	ReadOnlySuffix = "-readonly"
+)
+
+// SocketDir returns the directory that Wingo puts its sockets in. It is the
+// "wingo" directory in $XDG_RUNTIME_DIR, or in the system's temporary
+// directory if $XDG_RUNTIME_DIR isn't set.
+func SocketDir() string {
+	if xdgRuntime := os.Getenv("XDG_RUNTIME_DIR"); len(xdgRuntime) > 0 {
+		return path.Join(xdgRuntime, "wingo")
+	}
+	return path.Join(os.TempDir(), "wingo")
+}
+
+// SocketFile returns the path of the command socket of the Wingo instance
+// managing the given X display and screen.
+func SocketFile(display, screen int) string {
+	return path.Join(SocketDir(), fmt.Sprintf(":%d.%d", display, screen))
+}
+
+// SocketPath returns the path of the command socket of the Wingo instance
+// managing the X display in $DISPLAY.
+func SocketPath() (string, error) {
+	display, screen, err := parseDisplay(os.Getenv("DISPLAY"))
+	if err != nil {
+		return "", err
+	}
+	return SocketFile(display, screen), nil
+}
+
+// parseDisplay gets the display and screen numbers out of a display name like
+// ":0", ":1.0" or "localhost:10.1". The screen is 0 if it is left out.
+func parseDisplay(name string) (int, int, error) {
+	colon := strings.LastIndex(name, ":")
+	if colon == -1 {
+		return 0, 0, fmt.Errorf("invalid display name '%s'", name)
+	}
+
+	pieces := strings.SplitN(name[colon+1:], ".", 2)
+	display, err := strconv.Atoi(pieces[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid display name '%s'", name)
+	}
+	screen := 0
+	if len(pieces) == 2 {
+		if screen, err = strconv.Atoi(pieces[1]); err != nil {
+			return 0, 0, fmt.Errorf("invalid display name '%s'", name)
+		}
+	}
+	return display, screen, nil
+}
@@ -0,0 +1,251 @@
+package layout
+
+import (
+	"github.com/BurntSushi/xgbutil/xrect"
+)
+
+// CenteredMaster keeps the master clients in a column in the middle of the
+// screen, and alternates slave clients between a stack on the right and a
+// stack on the left. This is useful on very wide screens, where a master on
+// the far edge of the screen is a pain to look at.
+//
+// The order of clients is kept in a list, and the tree is rebuilt from that
+// list whenever a client is added or removed. The width of the master column
+// is remembered across rebuilds.
+type CenteredMaster struct {
+	store          *tree
+	root, masters  splitter
+	clients        []Client
+	leaves         []*leaf
+	allowedMasters int
+	masterProp     proportion
+	geom           xrect.Rect
+}
+
+func NewCenteredMaster() *CenteredMaster {
+	lay := &CenteredMaster{
+		store:          newTree(),
+		clients:        make([]Client, 0),
+		leaves:         make([]*leaf, 0),
+		allowedMasters: 1,
+		masterProp:     fullPortion / 2,
+	}
+	lay.rebuild()
+	return lay
+}
+
+func (lay *CenteredMaster) Name() string {
+	return "CenteredMaster"
+}
+
+func (lay *CenteredMaster) SetGeom(geom xrect.Rect) {
+	lay.geom = geom
+}
+
+func (lay *CenteredMaster) SetGaps(gaps Gaps) {
+	lay.store.gaps = gaps
+}
+
+func (lay *CenteredMaster) Place() {
+	lay.store.place(lay.geom)
+}
+
+func (lay *CenteredMaster) Unplace() {}
+
+func (lay *CenteredMaster) Destroy() {}
+
+func (lay *CenteredMaster) Exists(c Client) bool {
+	return lay.index(c) > -1
+}
+
+func (lay *CenteredMaster) Add(c Client) {
+	if lay.Exists(c) {
+		return
+	}
+	lay.clients = append(lay.clients, c)
+	lay.rebuild()
+}
+
+func (lay *CenteredMaster) Remove(c Client) {
+	if i := lay.index(c); i > -1 {
+		lay.clients = append(lay.clients[:i], lay.clients[i+1:]...)
+		lay.rebuild()
+	}
+}
+
+// ResizeMaster grows or shrinks the master column. The difference is taken
+// from (or given to) the slave stacks evenly, so the masters stay centered.
+func (lay *CenteredMaster) ResizeMaster(amount float64) {
+	if lay.masters.Size() == 0 || lay.root.Size() < 2 {
+		return
+	}
+	lay.root.PropsSave()
+
+	newProp := lay.masters.Proportion() + proportion(amount)
+	lay.root.SetChildProportion(lay.masters, newProp)
+
+	if lay.store.place(lay.geom) {
+		lay.root.PropsClear()
+		lay.masterProp = lay.masters.Proportion()
+	} else {
+		lay.root.PropsRollback()
+	}
+}
+
+func (lay *CenteredMaster) ResizeWindow(amount float64) {
+	i := lay.current()
+	if i == -1 || lay.leaves[i].parent.Size() < 2 {
+		return
+	}
+	lf := lay.leaves[i]
+	lf.parent.PropsSave()
+
+	newProp := lf.Proportion() + proportion(amount)
+	lf.parent.SetChildProportion(lf, newProp)
+
+	if lay.store.place(lay.geom) {
+		lf.parent.PropsClear()
+	} else {
+		lf.parent.PropsRollback()
+	}
+}
+
+func (lay *CenteredMaster) Next() {
+	if i := lay.current(); i > -1 {
+		lay.focus((i + 1) % len(lay.clients))
+	}
+}
+
+func (lay *CenteredMaster) Prev() {
+	if i := lay.current(); i > -1 {
+		lay.focus((i - 1 + len(lay.clients)) % len(lay.clients))
+	}
+}
+
+func (lay *CenteredMaster) SwitchNext() {
+	if i := lay.current(); i > -1 {
+		lay.switchClients(i, (i+1)%len(lay.clients))
+	}
+}
+
+func (lay *CenteredMaster) SwitchPrev() {
+	if i := lay.current(); i > -1 {
+		lay.switchClients(i, (i-1+len(lay.clients))%len(lay.clients))
+	}
+}
+
+func (lay *CenteredMaster) FocusMaster() {
+	if lay.masters.Size() > 0 {
+		lay.focus(0)
+	}
+}
+
+func (lay *CenteredMaster) MakeMaster() {
+	if i := lay.current(); i > -1 && lay.masters.Size() > 0 {
+		lay.switchClients(i, 0)
+	}
+}
+
+func (lay *CenteredMaster) MastersMore() {
+	lay.allowedMasters += 1
+	lay.rebuild()
+	lay.Place()
+}
+
+func (lay *CenteredMaster) MastersFewer() {
+	if lay.allowedMasters == 0 {
+		return
+	}
+	lay.allowedMasters -= 1
+	lay.rebuild()
+	lay.Place()
+}
+
+// rebuild throws away the current tree and builds a new one from the list
+// of clients. The first allowedMasters clients go in the master column, and
+// the rest alternate between the right and left stacks, starting with the
+// right.
+func (lay *CenteredMaster) rebuild() {
+	lay.root = newHSplit(nil)
+	lay.root.SetProportion(fullPortion)
+	lay.store.setChild(lay.root)
+	lay.leaves = lay.leaves[:0]
+
+	lay.masters = newVSplit(lay.root)
+	left, right := newVSplit(lay.root), newVSplit(lay.root)
+
+	nmasters := lay.allowedMasters
+	if nmasters > len(lay.clients) {
+		nmasters = len(lay.clients)
+	}
+	for i, c := range lay.clients {
+		var s splitter
+		switch {
+		case i < nmasters:
+			s = lay.masters
+		case (i-nmasters)%2 == 0:
+			s = right
+		default:
+			s = left
+		}
+		lf := newLeaf(s, c)
+		s.AddNode(lf, true)
+		lay.leaves = append(lay.leaves, lf)
+	}
+
+	// Empty columns are left out, so a lone stack gets all of the space that
+	// the masters don't use.
+	for _, s := range []splitter{left, lay.masters, right} {
+		if s.Size() > 0 {
+			lay.root.AddNode(s, true)
+		}
+	}
+	if lay.masters.Size() > 0 && lay.root.Size() > 1 {
+		lay.root.SetChildProportion(lay.masters, lay.masterProp)
+	}
+}
+
+func (lay *CenteredMaster) index(c Client) int {
+	for i, client := range lay.clients {
+		if client == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// current returns the index of the active client, or -1 if the active client
+// isn't in this layout.
+func (lay *CenteredMaster) current() int {
+	for i, c := range lay.clients {
+		if c.IsActive() {
+			return i
+		}
+	}
+	return -1
+}
+
+func (lay *CenteredMaster) focus(i int) {
+	c := lay.clients[i]
+	c.Focus()
+	c.Raise()
+}
+
+// switchClients swaps the clients at i and j without rebuilding the tree, so
+// that any resized windows stay put.
+func (lay *CenteredMaster) switchClients(i, j int) {
+	if i == j {
+		return
+	}
+	lay.clients[i], lay.clients[j] = lay.clients[j], lay.clients[i]
+	lay.store.switchClients(lay.leaves[i], lay.leaves[j])
+	lay.Place()
+}
+
+func (lay *CenteredMaster) MROpt(c Client, flags, x, y, width, height int) {}
+
+func (lay *CenteredMaster) MoveResize(c Client, x, y, width, height int) {}
+
+func (lay *CenteredMaster) Move(c Client, x, y int) {}
+
+func (lay *CenteredMaster) Resize(c Client, width, height int) {}
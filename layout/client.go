@@ -22,6 +22,8 @@ type Client interface {
 	Focus()
 	Raise()
 	IsActive() bool
+	PlacementPolicy() int
+	TransientFor() Client
 
 	MROpt(validate bool, flags, x, y, width, height int)
 	MoveResize(x, y, width, height int)
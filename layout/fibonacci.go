@@ -0,0 +1,276 @@
+package layout
+
+import (
+	"github.com/BurntSushi/xgbutil/xrect"
+)
+
+// fibonacci is a layout that gives each client half of the space left over
+// by the clients before it, alternating between horizontal and vertical
+// splits. In the "dwindle" variant, the remaining space is always to the
+// right or below. In the "spiral" variant, it rotates around the screen.
+//
+// Every client except the last owns the split in which it was placed. The
+// proportion of each split is remembered by depth, so that resizing a client
+// sticks even when clients are added or removed.
+type fibonacci struct {
+	store   *tree
+	clients []Client
+	splits  []splitter
+	leaves  []*leaf
+	props   []proportion
+	spiral  bool
+	geom    xrect.Rect
+}
+
+type Spiral struct {
+	fibonacci
+}
+
+type Dwindle struct {
+	fibonacci
+}
+
+func NewSpiral() *Spiral {
+	return &Spiral{newFibonacci(true)}
+}
+
+func NewDwindle() *Dwindle {
+	return &Dwindle{newFibonacci(false)}
+}
+
+func newFibonacci(spiral bool) fibonacci {
+	return fibonacci{
+		store:   newTree(),
+		clients: make([]Client, 0),
+		splits:  make([]splitter, 0),
+		leaves:  make([]*leaf, 0),
+		props:   make([]proportion, 0),
+		spiral:  spiral,
+	}
+}
+
+func (lay *Spiral) Name() string {
+	return "Spiral"
+}
+
+func (lay *Dwindle) Name() string {
+	return "Dwindle"
+}
+
+func (lay *fibonacci) SetGeom(geom xrect.Rect) {
+	lay.geom = geom
+}
+
+func (lay *fibonacci) SetGaps(gaps Gaps) {
+	lay.store.gaps = gaps
+}
+
+func (lay *fibonacci) Place() {
+	lay.store.place(lay.geom)
+}
+
+func (lay *fibonacci) Unplace() {}
+
+func (lay *fibonacci) Destroy() {}
+
+func (lay *fibonacci) Exists(c Client) bool {
+	return lay.index(c) > -1
+}
+
+func (lay *fibonacci) Add(c Client) {
+	if lay.Exists(c) {
+		return
+	}
+	lay.clients = append(lay.clients, c)
+	lay.rebuild()
+}
+
+func (lay *fibonacci) Remove(c Client) {
+	if i := lay.index(c); i > -1 {
+		lay.clients = append(lay.clients[:i], lay.clients[i+1:]...)
+		lay.rebuild()
+	}
+}
+
+// ResizeMaster resizes the split owned by the first client.
+func (lay *fibonacci) ResizeMaster(amount float64) {
+	if len(lay.leaves) > 0 {
+		lay.resizeLeaf(lay.leaves[0], amount)
+	}
+}
+
+// ResizeWindow resizes the split owned by the current client.
+func (lay *fibonacci) ResizeWindow(amount float64) {
+	if i := lay.current(); i > -1 {
+		lay.resizeLeaf(lay.leaves[i], amount)
+	}
+}
+
+func (lay *fibonacci) Next() {
+	if i := lay.current(); i > -1 {
+		lay.focus((i + 1) % len(lay.clients))
+	}
+}
+
+func (lay *fibonacci) Prev() {
+	if i := lay.current(); i > -1 {
+		lay.focus((i - 1 + len(lay.clients)) % len(lay.clients))
+	}
+}
+
+func (lay *fibonacci) SwitchNext() {
+	if i := lay.current(); i > -1 {
+		lay.switchClients(i, (i+1)%len(lay.clients))
+	}
+}
+
+func (lay *fibonacci) SwitchPrev() {
+	if i := lay.current(); i > -1 {
+		lay.switchClients(i, (i-1+len(lay.clients))%len(lay.clients))
+	}
+}
+
+func (lay *fibonacci) FocusMaster() {
+	if len(lay.clients) > 0 {
+		lay.focus(0)
+	}
+}
+
+func (lay *fibonacci) MakeMaster() {
+	if i := lay.current(); i > -1 {
+		lay.switchClients(i, 0)
+	}
+}
+
+func (lay *fibonacci) MastersMore() {}
+
+func (lay *fibonacci) MastersFewer() {}
+
+// rebuild throws away the current tree and builds a new one from the list
+// of clients and the remembered split proportions.
+func (lay *fibonacci) rebuild() {
+	lay.store.setChild(nil)
+	lay.splits = lay.splits[:0]
+	lay.leaves = lay.leaves[:0]
+
+	n := len(lay.clients)
+	if n == 0 {
+		return
+	}
+	for len(lay.props) < n-1 {
+		lay.props = append(lay.props, fullPortion/2)
+	}
+
+	// A lone client still needs a split to live in.
+	if n == 1 {
+		root := newHSplit(nil)
+		root.SetProportion(fullPortion)
+		lf := newLeaf(root, lay.clients[0])
+		root.AddNode(lf, true)
+		lay.store.setChild(root)
+		lay.splits = append(lay.splits, root)
+		lay.leaves = append(lay.leaves, lf)
+		return
+	}
+
+	var parent splitter = nil
+	for i := 0; i < n-1; i++ {
+		s := newSplit(i%2 == 0, parent)
+		if parent == nil {
+			s.SetProportion(fullPortion)
+			lay.store.setChild(s)
+		}
+
+		lf := newLeaf(s, lay.clients[i])
+		s.AddNode(lf, leafFirstAt(lay.spiral, i))
+		if parent != nil {
+			parent.AddNode(s, !leafFirstAt(lay.spiral, i-1))
+		}
+
+		lay.splits = append(lay.splits, s)
+		lay.leaves = append(lay.leaves, lf)
+		parent = s
+	}
+
+	// The last client shares the deepest split with the one before it.
+	last := newLeaf(parent, lay.clients[n-1])
+	parent.AddNode(last, !leafFirstAt(lay.spiral, n-2))
+	lay.leaves = append(lay.leaves, last)
+
+	for i, s := range lay.splits {
+		s.SetChildProportion(lay.leaves[i], lay.props[i])
+	}
+}
+
+// leafFirstAt returns true if the client in the split at the given depth
+// comes before the remaining space. In a spiral, every other pair of splits
+// puts the remaining space before the client instead of after it.
+func leafFirstAt(spiral bool, depth int) bool {
+	return !spiral || depth%4 < 2
+}
+
+func (lay *fibonacci) resizeLeaf(lf *leaf, amount float64) {
+	if lf.parent.Size() < 2 {
+		return
+	}
+	lf.parent.PropsSave()
+
+	newProp := lf.Proportion() + proportion(amount)
+	lf.parent.SetChildProportion(lf, newProp)
+
+	if lay.store.place(lay.geom) {
+		lf.parent.PropsClear()
+
+		// Remember the new proportions for the next rebuild.
+		for i, s := range lay.splits {
+			if s.Size() > 1 {
+				lay.props[i] = lay.leaves[i].Proportion()
+			}
+		}
+	} else {
+		lf.parent.PropsRollback()
+	}
+}
+
+func (lay *fibonacci) index(c Client) int {
+	for i, client := range lay.clients {
+		if client == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// current returns the index of the active client, or -1 if the active client
+// isn't in this layout.
+func (lay *fibonacci) current() int {
+	for i, c := range lay.clients {
+		if c.IsActive() {
+			return i
+		}
+	}
+	return -1
+}
+
+func (lay *fibonacci) focus(i int) {
+	c := lay.clients[i]
+	c.Focus()
+	c.Raise()
+}
+
+func (lay *fibonacci) switchClients(i, j int) {
+	if i == j {
+		return
+	}
+	lay.clients[i], lay.clients[j] = lay.clients[j], lay.clients[i]
+	lay.rebuild()
+	lay.Place()
+}
+
+func (lay *fibonacci) MROpt(c Client, flags, x, y, width, height int) {}
+
+func (lay *fibonacci) MoveResize(c Client, x, y, width, height int) {}
+
+func (lay *fibonacci) Move(c Client, x, y int) {}
+
+func (lay *fibonacci) Resize(c Client, width, height int) {}
@@ -1,37 +1,44 @@
 package layout
 
 import (
-	"math/rand"
-	"time"
-
+	"github.com/BurntSushi/xgbutil"
 	"github.com/BurntSushi/xgbutil/xrect"
 )
 
 type Floating struct {
+	X       *xgbutil.XUtil
 	clients []Client
 	geom    xrect.Rect
+	cascade int
 }
 
-func NewFloating() *Floating {
+func NewFloating(X *xgbutil.XUtil) *Floating {
 	return &Floating{
+		X:       X,
 		clients: make([]Client, 0),
 	}
 }
 
+// InitialPlacement moves a new client to a spot in the workspace decided by
+// the client's placement policy.
 func (f *Floating) InitialPlacement(c Client) {
-	rand.Seed(time.Now().UnixNano())
-	cgeom := c.Geom()
-
-	x, y := f.geom.X(), f.geom.Y()
-	xlimit := f.geom.Width() - cgeom.Width()
-	ylimit := f.geom.Height() - cgeom.Height()
-	if xlimit > 0 {
-		x += rand.Intn(xlimit)
+	if f.geom == nil {
+		return
 	}
-	if ylimit > 0 {
-		y += rand.Intn(ylimit)
+	switch c.PlacementPolicy() {
+	case PlaceCascade:
+		f.placeCascade(c)
+	case PlaceCentered:
+		f.placeCentered(c)
+	case PlacePointer:
+		f.placePointer(c)
+	case PlaceTransient:
+		f.placeTransient(c)
+	case PlaceRandom:
+		f.placeRandom(c)
+	default:
+		f.placeSmart(c)
 	}
-	f.Move(c, x, y)
 }
 
 func (f *Floating) Place()   {}
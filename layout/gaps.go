@@ -0,0 +1,45 @@
+package layout
+
+import (
+	"github.com/BurntSushi/xgbutil/xrect"
+)
+
+// Gaps is the empty space, in pixels, that tiling layouts leave between
+// clients (Inner) and between clients and the edges of the workspace (Outer).
+//
+// Layouts apply gaps in two steps. First, the workspace geometry is shrunk
+// with area. Then the layout carves cells out of that area as if there were
+// no gaps at all, and each cell is shrunk with cell. Since every cell loses
+// half of the inner gap on each side, neighboring cells end up Inner pixels
+// apart, and area makes up the difference at the edges.
+type Gaps struct {
+	Inner, Outer int
+}
+
+// area returns the geometry that cells should be carved out of.
+func (g Gaps) area(geom xrect.Rect) xrect.Rect {
+	lt := g.Outer - g.Inner/2
+	rb := g.Outer - (g.Inner - g.Inner/2)
+	return xrect.New(geom.X()+lt, geom.Y()+lt,
+		geom.Width()-lt-rb, geom.Height()-lt-rb)
+}
+
+// cell shrinks the cell given by the inner gap. The size of the cell is never
+// shrunk below one pixel.
+func (g Gaps) cell(x, y, w, h int) (int, int, int, int) {
+	x, y, w, h = x+g.Inner/2, y+g.Inner/2, w-g.Inner, h-g.Inner
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return x, y, w, h
+}
+
+// tile puts a client in the cell given, minus the inner gap.
+func (g Gaps) tile(c Client, x, y, w, h int) {
+	x, y, w, h = g.cell(x, y, w, h)
+	c.FrameTile()
+	c.MoveResize(x, y, w, h)
+}
@@ -0,0 +1,169 @@
+package layout
+
+import (
+	"math"
+
+	"github.com/BurntSushi/xgbutil/xrect"
+)
+
+// Grid tiles clients in a near-square grid of rows and columns. When the
+// number of clients doesn't fill the grid, the clients in the last row are
+// spread evenly over its width. The master is simply the first cell.
+type Grid struct {
+	clients []Client
+	gaps    Gaps
+	geom    xrect.Rect
+}
+
+func NewGrid() *Grid {
+	return &Grid{
+		clients: make([]Client, 0),
+	}
+}
+
+func (g *Grid) Name() string {
+	return "Grid"
+}
+
+func (g *Grid) SetGeom(geom xrect.Rect) {
+	g.geom = geom
+}
+
+func (g *Grid) SetGaps(gaps Gaps) {
+	g.gaps = gaps
+}
+
+func (g *Grid) Place() {
+	if g.geom == nil || len(g.clients) == 0 {
+		return
+	}
+
+	n := len(g.clients)
+	cols := int(math.Ceil(math.Sqrt(float64(n))))
+	rows := (n + cols - 1) / cols
+
+	area := g.gaps.area(g.geom)
+	x, y, w, h := area.X(), area.Y(), area.Width(), area.Height()
+	for i, c := range g.clients {
+		row, col := i/cols, i%cols
+
+		// The last row may have fewer clients than there are columns.
+		rowCols := cols
+		if row == rows-1 {
+			rowCols = n - (rows-1)*cols
+		}
+
+		// Compute the edges of each cell from the total size so that
+		// rounding errors don't leave gaps between cells.
+		cx1, cx2 := x+col*w/rowCols, x+(col+1)*w/rowCols
+		cy1, cy2 := y+row*h/rows, y+(row+1)*h/rows
+
+		g.gaps.tile(c, cx1, cy1, cx2-cx1, cy2-cy1)
+	}
+}
+
+func (g *Grid) Unplace() {}
+
+func (g *Grid) Destroy() {}
+
+func (g *Grid) Exists(c Client) bool {
+	return g.index(c) > -1
+}
+
+func (g *Grid) Add(c Client) {
+	if !g.Exists(c) {
+		g.clients = append(g.clients, c)
+	}
+}
+
+func (g *Grid) Remove(c Client) {
+	if i := g.index(c); i > -1 {
+		g.clients = append(g.clients[:i], g.clients[i+1:]...)
+	}
+}
+
+func (g *Grid) ResizeMaster(amount float64) {}
+
+func (g *Grid) ResizeWindow(amount float64) {}
+
+func (g *Grid) Next() {
+	if i := g.current(); i > -1 {
+		g.focus((i + 1) % len(g.clients))
+	}
+}
+
+func (g *Grid) Prev() {
+	if i := g.current(); i > -1 {
+		g.focus((i - 1 + len(g.clients)) % len(g.clients))
+	}
+}
+
+func (g *Grid) SwitchNext() {
+	if i := g.current(); i > -1 {
+		g.switchClients(i, (i+1)%len(g.clients))
+	}
+}
+
+func (g *Grid) SwitchPrev() {
+	if i := g.current(); i > -1 {
+		g.switchClients(i, (i-1+len(g.clients))%len(g.clients))
+	}
+}
+
+func (g *Grid) FocusMaster() {
+	if len(g.clients) > 0 {
+		g.focus(0)
+	}
+}
+
+func (g *Grid) MakeMaster() {
+	if i := g.current(); i > -1 {
+		g.switchClients(i, 0)
+	}
+}
+
+func (g *Grid) MastersMore() {}
+
+func (g *Grid) MastersFewer() {}
+
+func (g *Grid) index(c Client) int {
+	for i, client := range g.clients {
+		if client == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// current returns the index of the active client, or -1 if the active client
+// isn't in this layout.
+func (g *Grid) current() int {
+	for i, c := range g.clients {
+		if c.IsActive() {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Grid) focus(i int) {
+	c := g.clients[i]
+	c.Focus()
+	c.Raise()
+}
+
+func (g *Grid) switchClients(i, j int) {
+	if i == j {
+		return
+	}
+	g.clients[i], g.clients[j] = g.clients[j], g.clients[i]
+	g.Place()
+}
+
+func (g *Grid) MROpt(c Client, flags, x, y, width, height int) {}
+
+func (g *Grid) MoveResize(c Client, x, y, width, height int) {}
+
+func (g *Grid) Move(c Client, x, y int) {}
+
+func (g *Grid) Resize(c Client, width, height int) {}
@@ -12,6 +12,10 @@ const (
 	AutoTileVertical = iota
 )
 
+const (
+	ManualTileManual = iota
+)
+
 type Layout interface {
 	Name() string
 	SetGeom(geom xrect.Rect)
@@ -37,6 +41,7 @@ type Floater interface {
 
 type AutoTiler interface {
 	Layout
+	SetGaps(gaps Gaps)
 	ResizeMaster(amount float64)
 	ResizeWindow(amount float64)
 	Next()
@@ -48,3 +53,34 @@ type AutoTiler interface {
 	MastersMore()
 	MastersFewer()
 }
+
+// Scroller is an AutoTiler that lays out clients in columns on a strip that
+// can be scrolled.
+type Scroller interface {
+	AutoTiler
+	ColumnWidth(amount float64)
+	ColumnMoveLeft()
+	ColumnMoveRight()
+	ColumnStack()
+	ColumnUnstack()
+}
+
+type ManualTiler interface {
+	Layout
+	SetGaps(gaps Gaps)
+	SplitHorizontal()
+	SplitVertical()
+	MoveLeft()
+	MoveRight()
+	MoveUp()
+	MoveDown()
+	ResizeWidth(amount float64)
+	ResizeHeight(amount float64)
+}
+
+// Decorator is implemented by layouts that draw something on behalf of their
+// clients (like tabs) or that follow the focus around (like Paper), and
+// therefore need to know when the name or focus state of a client changes.
+type Decorator interface {
+	Redecorate(c Client)
+}
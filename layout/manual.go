@@ -0,0 +1,326 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/xgbutil/xrect"
+)
+
+// Manual is a tiling layout where the user decides where each client goes.
+// Clients are stored in a tree of horizontal and vertical splits. A new
+// client is always added right after the current client in the current
+// client's split. The orientation of that split can be chosen with
+// SplitHorizontal and SplitVertical *before* the new client is opened.
+type Manual struct {
+	store *tree
+	geom  xrect.Rect
+}
+
+func NewManual() *Manual {
+	lay := &Manual{
+		store: newTree(),
+	}
+
+	root := newHSplit(nil)
+	root.SetProportion(fullPortion)
+	lay.store.setChild(root)
+
+	return lay
+}
+
+func (m *Manual) Name() string {
+	return "Manual"
+}
+
+func (m *Manual) SetGeom(geom xrect.Rect) {
+	m.geom = geom
+}
+
+func (m *Manual) SetGaps(gaps Gaps) {
+	m.store.gaps = gaps
+}
+
+func (m *Manual) Place() {
+	m.store.place(m.geom)
+}
+
+func (m *Manual) Unplace() {}
+
+func (m *Manual) Destroy() {}
+
+func (m *Manual) Exists(c Client) bool {
+	return m.store.findLeaf(c) != nil
+}
+
+func (m *Manual) Add(c Client) {
+	if m.Exists(c) {
+		return
+	}
+
+	// New clients go right after the current client. If there is no current
+	// client, it is simply tacked on to the end of the root split.
+	parent, index := m.root(), m.root().Size()
+	if lf := m.leafCurrent(); lf != nil {
+		parent, index = lf.parent, lf.parent.ChildIndex(lf)+1
+	}
+	parent.InsertNode(newLeaf(parent, c), index)
+}
+
+func (m *Manual) Remove(c Client) {
+	if lf := m.store.findLeaf(c); lf != nil {
+		m.detach(lf)
+	}
+}
+
+// SplitHorizontal makes the next client open to the right of the current
+// client.
+func (m *Manual) SplitHorizontal() {
+	m.split(true)
+}
+
+// SplitVertical makes the next client open below the current client.
+func (m *Manual) SplitVertical() {
+	m.split(false)
+}
+
+func (m *Manual) MoveLeft() {
+	m.move(true, false)
+}
+
+func (m *Manual) MoveRight() {
+	m.move(true, true)
+}
+
+func (m *Manual) MoveUp() {
+	m.move(false, false)
+}
+
+func (m *Manual) MoveDown() {
+	m.move(false, true)
+}
+
+// ResizeWidth grows or shrinks the width of the current client by amount,
+// where amount is a ratio of the width of the closest horizontal split
+// containing the current client.
+func (m *Manual) ResizeWidth(amount float64) {
+	m.resize(true, amount)
+}
+
+// ResizeHeight grows or shrinks the height of the current client by amount,
+// where amount is a ratio of the height of the closest vertical split
+// containing the current client.
+func (m *Manual) ResizeHeight(amount float64) {
+	m.resize(false, amount)
+}
+
+func (m *Manual) root() splitter {
+	return m.store.child.(splitter)
+}
+
+func (m *Manual) leafCurrent() *leaf {
+	var lf *leaf
+	m.store.child.VisitLeafNodes(func(visit *leaf) bool {
+		if visit.client.IsActive() {
+			lf = visit
+			return false
+		}
+		return true
+	})
+	return lf
+}
+
+func (m *Manual) split(horizontal bool) {
+	lf := m.leafCurrent()
+	if lf == nil {
+		return
+	}
+
+	// If the current client is alone in its split, there's no need to nest
+	// another split. Just change the orientation of the one we've got.
+	if lf.parent.Size() == 1 {
+		m.reorient(lf.parent, horizontal)
+		m.Place()
+		return
+	}
+
+	s := newSplit(horizontal, lf.parent)
+	lf.parent.ReplaceNode(lf, s)
+	lf.SetParent(s)
+	s.AddNode(lf, true)
+}
+
+// move moves the current client in the direction given. The client is
+// swapped with its neighbor in the closest split with the right orientation.
+// If that neighbor is itself a split, the client is moved into it instead.
+// If the client is at the edge of its split, it is moved out of that split
+// and into the closest ancestor split with the right orientation.
+func (m *Manual) move(horizontal, forward bool) {
+	lf := m.leafCurrent()
+	if lf == nil {
+		return
+	}
+
+	// Moving a client undoes a pending split on it.
+	if lf.parent.Size() == 1 && lf.parent.Parent() != nil {
+		pending := lf.parent
+		grand := pending.Parent().(splitter)
+		grand.ReplaceNode(pending, lf)
+		lf.SetParent(grand)
+	}
+
+	// Find the split to move the client within, along with the child of that
+	// split that contains the client.
+	var child node = lf
+	parent := lf.parent
+	for {
+		if isHorizontal(parent) == horizontal {
+			i := parent.ChildIndex(child)
+			if child != lf ||
+				(forward && i < parent.Size()-1) || (!forward && i > 0) {
+
+				break
+			}
+		}
+		if parent.Parent() == nil {
+			// We've hit the root. If it has the right orientation, then the
+			// client is already as far as it can go.
+			if isHorizontal(parent) == horizontal {
+				return
+			}
+
+			// Otherwise, wrap the root in a new split with the right
+			// orientation so the client has somewhere to go.
+			newRoot := newSplit(horizontal, nil)
+			newRoot.SetProportion(fullPortion)
+			parent.SetParent(newRoot)
+			newRoot.AddNode(parent, true)
+			m.store.setChild(newRoot)
+		}
+		child, parent = parent, parent.Parent().(splitter)
+	}
+
+	i := parent.ChildIndex(child)
+	if child == lf {
+		j := i - 1
+		if forward {
+			j = i + 1
+		}
+		switch sibling := parent.Child(j).(type) {
+		case *leaf:
+			m.store.switchClients(lf, sibling)
+		case splitter:
+			m.detach(lf)
+			if forward {
+				sibling.InsertNode(lf, 0)
+			} else {
+				sibling.InsertNode(lf, sibling.Size())
+			}
+			lf.SetParent(sibling)
+		default:
+			panic(fmt.Sprintf("Unknown node type: %T", sibling))
+		}
+	} else {
+		if forward {
+			i++
+		}
+		m.detach(lf)
+		parent.InsertNode(lf, i)
+		lf.SetParent(parent)
+	}
+	m.Place()
+}
+
+func (m *Manual) resize(horizontal bool, amount float64) {
+	lf := m.leafCurrent()
+	if lf == nil {
+		return
+	}
+
+	var child node = lf
+	parent := lf.parent
+	for isHorizontal(parent) != horizontal || parent.Size() < 2 {
+		if parent.Parent() == nil {
+			return
+		}
+		child, parent = parent, parent.Parent().(splitter)
+	}
+
+	parent.PropsSave()
+
+	newProp := child.Proportion() + proportion(amount)
+	parent.SetChildProportion(child, newProp)
+
+	if m.store.place(m.geom) {
+		parent.PropsClear()
+	} else {
+		parent.PropsRollback()
+	}
+}
+
+// detach removes n from its split, and cleans up any splits that have
+// become empty (or only have one child) as a result.
+func (m *Manual) detach(n node) {
+	parent := n.Parent().(splitter)
+	parent.RemoveNode(n)
+
+	// The root split always stays put.
+	if parent.Parent() == nil {
+		return
+	}
+	switch parent.Size() {
+	case 0:
+		m.detach(parent)
+	case 1:
+		only := parent.Child(0)
+		grand := parent.Parent().(splitter)
+		grand.ReplaceNode(parent, only)
+		only.SetParent(grand)
+	}
+}
+
+// reorient replaces s with a split of the given orientation that has all of
+// the same children.
+func (m *Manual) reorient(s splitter, horizontal bool) {
+	if isHorizontal(s) == horizontal {
+		return
+	}
+
+	ns := newSplit(horizontal, s.Parent())
+	for i := 0; i < s.Size(); i++ {
+		child := s.Child(i)
+		child.SetParent(ns)
+		ns.AddNode(child, true)
+	}
+	for i := 0; i < s.Size(); i++ {
+		ns.Child(i).SetProportion(s.Child(i).Proportion())
+	}
+
+	if s.Parent() == nil {
+		ns.SetProportion(fullPortion)
+		m.store.setChild(ns)
+	} else {
+		s.Parent().(splitter).ReplaceNode(s, ns)
+	}
+}
+
+func (m *Manual) MROpt(c Client, flags, x, y, width, height int) {}
+
+func (m *Manual) MoveResize(c Client, x, y, width, height int) {}
+
+func (m *Manual) Move(c Client, x, y int) {}
+
+func (m *Manual) Resize(c Client, width, height int) {}
+
+// newSplit creates a new horizontal or vertical split.
+func newSplit(horizontal bool, parent node) splitter {
+	if horizontal {
+		return newHSplit(parent)
+	}
+	return newVSplit(parent)
+}
+
+// isHorizontal returns true if n is a horizontal split.
+func isHorizontal(n node) bool {
+	_, ok := n.(*hsplit)
+	return ok
+}
@@ -8,6 +8,7 @@ import (
 
 type Maximized struct {
 	clients *list.List
+	gaps    Gaps
 	geom    xrect.Rect
 }
 
@@ -25,12 +26,16 @@ func (m *Maximized) SetGeom(geom xrect.Rect) {
 	m.geom = geom
 }
 
+func (m *Maximized) SetGaps(gaps Gaps) {
+	m.gaps = gaps
+}
+
 func (m *Maximized) Place() {
 	for el := m.clients.Front(); el != nil; el = el.Next() {
 		c := el.Value.(Client)
-		x, y, w, h := m.geom.X(), m.geom.Y(), m.geom.Width(), m.geom.Height()
-		c.FrameTile()
-		c.MoveResize(x, y, w, h)
+		area := m.gaps.area(m.geom)
+		x, y, w, h := area.X(), area.Y(), area.Width(), area.Height()
+		m.gaps.tile(c, x, y, w, h)
 	}
 }
 
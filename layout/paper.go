@@ -0,0 +1,322 @@
+package layout
+
+import (
+	"github.com/BurntSushi/xgbutil/xrect"
+)
+
+const (
+	paperDefaultWidth proportion = 0.5
+	paperMinWidth     proportion = 0.1
+)
+
+// Paper lays out clients in columns on a horizontal strip that may be much
+// wider than the head. Each column has its own width, as a proportion of the
+// width of the head, and may hold more than one client stacked vertically.
+// The strip is scrolled so that the column of the current client is always
+// entirely in view. Columns that aren't entirely in view are moved off the
+// screen, so that they don't spill over on to other heads.
+type Paper struct {
+	columns []*column
+	cur     Client
+	offset  int
+	gaps    Gaps
+	geom    xrect.Rect
+}
+
+type column struct {
+	clients []Client
+	width   proportion
+}
+
+func NewPaper() *Paper {
+	return &Paper{
+		columns: make([]*column, 0),
+	}
+}
+
+func (p *Paper) Name() string {
+	return "Paper"
+}
+
+func (p *Paper) SetGeom(geom xrect.Rect) {
+	p.geom = geom
+}
+
+func (p *Paper) SetGaps(gaps Gaps) {
+	p.gaps = gaps
+}
+
+func (p *Paper) Place() {
+	if p.geom == nil || len(p.columns) == 0 {
+		return
+	}
+	area := p.gaps.area(p.geom)
+	x, y, w, h := area.X(), area.Y(), area.Width(), area.Height()
+
+	// Find the left edge of every column on the strip, and scroll the strip
+	// so that the current column is in view.
+	lefts := make([]int, len(p.columns))
+	for i, left := 0, 0; i < len(p.columns); i++ {
+		lefts[i] = left
+		left += p.columns[i].width.portion(w)
+	}
+	if ci, _ := p.find(p.cur); ci > -1 {
+		cw := p.columns[ci].width.portion(w)
+		if lefts[ci] < p.offset {
+			p.offset = lefts[ci]
+		} else if lefts[ci]+cw > p.offset+w {
+			p.offset = lefts[ci] + cw - w
+		}
+	}
+
+	for i, col := range p.columns {
+		cx, cw := x+lefts[i]-p.offset, col.width.portion(w)
+		if cx < x || cx+cw > x+w {
+			// Root window coordinates are never negative, so this is
+			// guaranteed to be off every head.
+			cx = -cw - 1
+		}
+		for j, c := range col.clients {
+			// Compute the edges of each client from the total height so that
+			// rounding errors don't leave gaps between clients.
+			n := len(col.clients)
+			cy1, cy2 := y+j*h/n, y+(j+1)*h/n
+
+			p.gaps.tile(c, cx, cy1, cw, cy2-cy1)
+		}
+	}
+}
+
+func (p *Paper) Unplace() {}
+
+func (p *Paper) Destroy() {}
+
+func (p *Paper) Exists(c Client) bool {
+	ci, _ := p.find(c)
+	return ci > -1
+}
+
+// Add puts the new client in its own column, right after the current column.
+func (p *Paper) Add(c Client) {
+	if p.Exists(c) {
+		return
+	}
+	col := &column{clients: []Client{c}, width: paperDefaultWidth}
+	ci, _ := p.find(p.cur)
+	p.insertColumn(col, ci+1)
+	p.cur = c
+}
+
+func (p *Paper) Remove(c Client) {
+	ci, i := p.find(c)
+	if ci == -1 {
+		return
+	}
+	p.removeClient(ci, i)
+
+	// Pick a neighbor to be current, so that the strip doesn't jump around.
+	if c == p.cur {
+		p.cur = nil
+		if len(p.columns) > 0 {
+			if ci >= len(p.columns) {
+				ci = len(p.columns) - 1
+			}
+			p.cur = p.columns[ci].clients[0]
+		}
+	}
+}
+
+// Redecorate scrolls the strip to a client when it is focused, since the
+// client may have been focused by something other than this layout.
+func (p *Paper) Redecorate(c Client) {
+	if c.IsActive() && c != p.cur && p.Exists(c) {
+		p.cur = c
+		p.Place()
+	}
+}
+
+func (p *Paper) ResizeMaster(amount float64) {}
+
+// ResizeWindow changes the width of the current column.
+func (p *Paper) ResizeWindow(amount float64) {
+	p.ColumnWidth(amount)
+}
+
+func (p *Paper) Next() {
+	if all := p.all(); len(all) > 0 {
+		p.focus(all[(p.index(all, p.current())+1)%len(all)])
+	}
+}
+
+func (p *Paper) Prev() {
+	if all := p.all(); len(all) > 0 {
+		i := p.index(all, p.current())
+		p.focus(all[(i-1+len(all))%len(all)])
+	}
+}
+
+func (p *Paper) SwitchNext() {
+	p.ColumnMoveRight()
+}
+
+func (p *Paper) SwitchPrev() {
+	p.ColumnMoveLeft()
+}
+
+func (p *Paper) FocusMaster() {
+	if len(p.columns) > 0 {
+		p.focus(p.columns[0].clients[0])
+	}
+}
+
+// MakeMaster moves the current column to the start of the strip.
+func (p *Paper) MakeMaster() {
+	if ci, _ := p.find(p.current()); ci > 0 {
+		col := p.columns[ci]
+		p.columns = append(p.columns[:ci], p.columns[ci+1:]...)
+		p.insertColumn(col, 0)
+		p.Place()
+	}
+}
+
+func (p *Paper) MastersMore() {}
+
+func (p *Paper) MastersFewer() {}
+
+// ColumnWidth grows or shrinks the current column by amount, which is a
+// ratio of the width of the head.
+func (p *Paper) ColumnWidth(amount float64) {
+	ci, _ := p.find(p.current())
+	if ci == -1 {
+		return
+	}
+	col := p.columns[ci]
+	col.width += proportion(amount)
+	if col.width < paperMinWidth {
+		col.width = paperMinWidth
+	}
+	if col.width > fullPortion {
+		col.width = fullPortion
+	}
+	p.Place()
+}
+
+func (p *Paper) ColumnMoveLeft() {
+	if ci, _ := p.find(p.current()); ci > 0 {
+		p.columns[ci-1], p.columns[ci] = p.columns[ci], p.columns[ci-1]
+		p.Place()
+	}
+}
+
+func (p *Paper) ColumnMoveRight() {
+	if ci, _ := p.find(p.current()); ci > -1 && ci < len(p.columns)-1 {
+		p.columns[ci+1], p.columns[ci] = p.columns[ci], p.columns[ci+1]
+		p.Place()
+	}
+}
+
+// ColumnStack moves the current client to the bottom of the column to its
+// left.
+func (p *Paper) ColumnStack() {
+	c := p.current()
+	ci, i := p.find(c)
+	if ci < 1 {
+		return
+	}
+	left := p.columns[ci-1]
+	p.removeClient(ci, i)
+	left.clients = append(left.clients, c)
+	p.Place()
+}
+
+// ColumnUnstack moves the current client out of its column and into a new
+// column to the right of it. Nothing happens if the client is already alone
+// in its column.
+func (p *Paper) ColumnUnstack() {
+	c := p.current()
+	ci, i := p.find(c)
+	if ci == -1 || len(p.columns[ci].clients) < 2 {
+		return
+	}
+	width := p.columns[ci].width
+	p.removeClient(ci, i)
+	p.insertColumn(&column{clients: []Client{c}, width: width}, ci+1)
+	p.Place()
+}
+
+// current returns the active client if it's in this layout. Otherwise, the
+// last client that was current is returned.
+func (p *Paper) current() Client {
+	for _, col := range p.columns {
+		for _, c := range col.clients {
+			if c.IsActive() {
+				p.cur = c
+				return c
+			}
+		}
+	}
+	return p.cur
+}
+
+// find returns the column index and the index within that column of the
+// client given. If the client isn't in this layout, (-1, -1) is returned.
+func (p *Paper) find(c Client) (int, int) {
+	for ci, col := range p.columns {
+		for i, client := range col.clients {
+			if client == c {
+				return ci, i
+			}
+		}
+	}
+	return -1, -1
+}
+
+// all returns every client on the strip, from left to right and top to
+// bottom.
+func (p *Paper) all() []Client {
+	all := make([]Client, 0)
+	for _, col := range p.columns {
+		all = append(all, col.clients...)
+	}
+	return all
+}
+
+func (p *Paper) index(clients []Client, c Client) int {
+	for i, client := range clients {
+		if client == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *Paper) focus(c Client) {
+	p.cur = c
+	c.Focus()
+	c.Raise()
+	p.Place()
+}
+
+func (p *Paper) insertColumn(col *column, i int) {
+	p.columns = append(p.columns, nil)
+	copy(p.columns[i+1:], p.columns[i:])
+	p.columns[i] = col
+}
+
+// removeClient removes the i'th client from the column at ci. The column is
+// removed too if it's left empty.
+func (p *Paper) removeClient(ci, i int) {
+	col := p.columns[ci]
+	col.clients = append(col.clients[:i], col.clients[i+1:]...)
+	if len(col.clients) == 0 {
+		p.columns = append(p.columns[:ci], p.columns[ci+1:]...)
+	}
+}
+
+func (p *Paper) MROpt(c Client, flags, x, y, width, height int) {}
+
+func (p *Paper) MoveResize(c Client, x, y, width, height int) {}
+
+func (p *Paper) Move(c Client, x, y int) {}
+
+func (p *Paper) Resize(c Client, width, height int) {}
@@ -0,0 +1,186 @@
+package layout
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/xgb/xproto"
+
+	"github.com/BurntSushi/xgbutil/xrect"
+
+	"github.com/BurntSushi/wingo/logger"
+)
+
+// Policies for placing new clients in the floating layout.
+const (
+	PlaceSmart = iota
+	PlaceCascade
+	PlaceCentered
+	PlacePointer
+	PlaceTransient
+	PlaceRandom
+)
+
+// cascadeStep is the distance, in pixels, between clients placed with the
+// cascade policy.
+const cascadeStep = 30
+
+var placementNames = map[string]int{
+	"smart":            PlaceSmart,
+	"cascade":          PlaceCascade,
+	"centered":         PlaceCentered,
+	"under-pointer":    PlacePointer,
+	"transient-parent": PlaceTransient,
+	"random":           PlaceRandom,
+}
+
+// PlacementPolicy returns the placement policy with the given name. The name
+// is case insensitive. If there is no policy with that name, false is
+// returned.
+func PlacementPolicy(name string) (int, bool) {
+	policy, ok := placementNames[strings.ToLower(strings.TrimSpace(name))]
+	return policy, ok
+}
+
+// placeSmart puts c where it overlaps the least with the other floating
+// clients on the workspace. The candidate positions are the corners of the
+// workspace and the positions that line c up against the edges of the other
+// clients. Ties go to the position closest to the top-left corner.
+func (f *Floating) placeSmart(c Client) {
+	cgeom := c.Geom()
+	cw, ch := cgeom.Width(), cgeom.Height()
+	others := make([]xrect.Rect, 0, len(f.clients))
+	for _, other := range f.clients {
+		if other == c {
+			continue
+		}
+		if _, ok := other.Layout().(*Floating); ok {
+			others = append(others, other.Geom())
+		}
+	}
+
+	gx, gy, gw, gh := f.geom.X(), f.geom.Y(), f.geom.Width(), f.geom.Height()
+	xs := []int{gx, gx + gw - cw}
+	ys := []int{gy, gy + gh - ch}
+	for _, o := range others {
+		xs = append(xs, o.X()+o.Width(), o.X()-cw)
+		ys = append(ys, o.Y()+o.Height(), o.Y()-ch)
+	}
+
+	bestx, besty, best := gx, gy, -1
+	for _, x := range xs {
+		for _, y := range ys {
+			x, y := f.clamp(x, y, cw, ch)
+			overlap := 0
+			for _, o := range others {
+				overlap += intersection(x, y, cw, ch, o)
+			}
+			better := best == -1 || overlap < best ||
+				(overlap == best && x-gx+y-gy < bestx-gx+besty-gy)
+			if better {
+				bestx, besty, best = x, y, overlap
+			}
+		}
+	}
+	f.Move(c, bestx, besty)
+}
+
+// placeCascade puts each new client a little below and to the right of the
+// last one, starting over at the top-left corner when a client would no
+// longer fit.
+func (f *Floating) placeCascade(c Client) {
+	cgeom := c.Geom()
+	x := f.geom.X() + f.cascade*cascadeStep
+	y := f.geom.Y() + f.cascade*cascadeStep
+	if x+cgeom.Width() > f.geom.X()+f.geom.Width() ||
+		y+cgeom.Height() > f.geom.Y()+f.geom.Height() {
+
+		f.cascade = 0
+		x, y = f.geom.X(), f.geom.Y()
+	}
+	f.cascade++
+	f.Move(c, x, y)
+}
+
+func (f *Floating) placeCentered(c Client) {
+	f.placeCenteredOn(c, f.geom)
+}
+
+// placeCenteredOn puts c in the center of geom, but always inside the
+// workspace.
+func (f *Floating) placeCenteredOn(c Client, geom xrect.Rect) {
+	cw, ch := c.Geom().Width(), c.Geom().Height()
+	x, y := f.clamp(geom.X()+(geom.Width()-cw)/2,
+		geom.Y()+(geom.Height()-ch)/2, cw, ch)
+	f.Move(c, x, y)
+}
+
+func (f *Floating) placePointer(c Client) {
+	reply, err := xproto.QueryPointer(f.X.Conn(), f.X.RootWin()).Reply()
+	if err != nil {
+		logger.Warning.Printf("Could not get pointer position: %s", err)
+		f.placeCentered(c)
+		return
+	}
+	cw, ch := c.Geom().Width(), c.Geom().Height()
+	x, y := f.clamp(int(reply.RootX)-cw/2, int(reply.RootY)-ch/2, cw, ch)
+	f.Move(c, x, y)
+}
+
+// placeTransient centers a transient client on the client it is transient
+// for. Clients that aren't transient are placed with the smart policy.
+func (f *Floating) placeTransient(c Client) {
+	if parent := c.TransientFor(); parent != nil {
+		f.placeCenteredOn(c, parent.Geom())
+	} else {
+		f.placeSmart(c)
+	}
+}
+
+func (f *Floating) placeRandom(c Client) {
+	rand.Seed(time.Now().UnixNano())
+	cgeom := c.Geom()
+
+	x, y := f.geom.X(), f.geom.Y()
+	xlimit := f.geom.Width() - cgeom.Width()
+	ylimit := f.geom.Height() - cgeom.Height()
+	if xlimit > 0 {
+		x += rand.Intn(xlimit)
+	}
+	if ylimit > 0 {
+		y += rand.Intn(ylimit)
+	}
+	f.Move(c, x, y)
+}
+
+// clamp moves the rectangle given so that it is inside the workspace. If the
+// rectangle is too big to fit, it is lined up with the top-left corner.
+func (f *Floating) clamp(x, y, w, h int) (int, int) {
+	gx, gy, gw, gh := f.geom.X(), f.geom.Y(), f.geom.Width(), f.geom.Height()
+	if x+w > gx+gw {
+		x = gx + gw - w
+	}
+	if y+h > gy+gh {
+		y = gy + gh - h
+	}
+	if x < gx {
+		x = gx
+	}
+	if y < gy {
+		y = gy
+	}
+	return x, y
+}
+
+// intersection returns the area of the intersection of the rectangle given
+// and r.
+func intersection(x, y, w, h int, r xrect.Rect) int {
+	x1, y1 := max(x, r.X()), max(y, r.Y())
+	x2 := min(x+w, r.X()+r.Width())
+	y2 := min(y+h, r.Y()+r.Height())
+	if x2 <= x1 || y2 <= y1 {
+		return 0
+	}
+	return (x2 - x1) * (y2 - y1)
+}
@@ -0,0 +1,410 @@
+package layout
+
+import (
+	"github.com/BurntSushi/freetype-go/freetype/truetype"
+
+	"github.com/BurntSushi/xgb/xproto"
+
+	"github.com/BurntSushi/xgbutil"
+	"github.com/BurntSushi/xgbutil/mousebind"
+	"github.com/BurntSushi/xgbutil/xevent"
+	"github.com/BurntSushi/xgbutil/xgraphics"
+	"github.com/BurntSushi/xgbutil/xrect"
+	"github.com/BurntSushi/xgbutil/xwindow"
+
+	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/render"
+)
+
+// TabTheme values are used to draw the tabs in the Tabbed and Stacked
+// layouts. They typically come from the theme of the Full frame.
+type TabTheme struct {
+	Font                   *truetype.Font
+	FontSize               float64
+	AFontColor, IFontColor render.Color
+
+	TabSize              int
+	ATabColor, ITabColor render.Color
+	BorderColor          render.Color
+}
+
+// tabbed is a layout where every client is maximized, and a bar with one
+// tab for each client is drawn above them. Clicking a tab shows and focuses
+// its client. In the "tabbed" variant, the tabs are laid out in a single row.
+// In the "stacked" variant, each tab gets its own row.
+//
+// The bar and the tab windows only exist while the layout is placed and the
+// bar is shown. Otherwise, bar is nil and so is every element of tabs.
+type tabbed struct {
+	X       *xgbutil.XUtil
+	theme   *TabTheme
+	bar     *xwindow.Window
+	tabs    []*tab
+	clients []Client
+	cur     int
+	stacked bool
+	gaps    Gaps
+	geom    xrect.Rect
+}
+
+type tab struct {
+	win    *xwindow.Window
+	pixmap xproto.Pixmap
+}
+
+type Tabbed struct {
+	tabbed
+}
+
+type Stacked struct {
+	tabbed
+}
+
+func NewTabbed(X *xgbutil.XUtil, theme *TabTheme) *Tabbed {
+	return &Tabbed{newTabbed(X, theme, false)}
+}
+
+func NewStacked(X *xgbutil.XUtil, theme *TabTheme) *Stacked {
+	return &Stacked{newTabbed(X, theme, true)}
+}
+
+func newTabbed(X *xgbutil.XUtil, theme *TabTheme, stacked bool) tabbed {
+	return tabbed{
+		X:       X,
+		theme:   theme,
+		tabs:    make([]*tab, 0),
+		clients: make([]Client, 0),
+		stacked: stacked,
+	}
+}
+
+func (lay *Tabbed) Name() string {
+	return "Tabbed"
+}
+
+func (lay *Stacked) Name() string {
+	return "Stacked"
+}
+
+func (lay *tabbed) SetGeom(geom xrect.Rect) {
+	lay.geom = geom
+	if geom == nil {
+		lay.destroyBar()
+	}
+}
+
+func (lay *tabbed) SetGaps(gaps Gaps) {
+	lay.gaps = gaps
+}
+
+func (lay *tabbed) Place() {
+	if lay.geom == nil || len(lay.clients) == 0 {
+		lay.destroyBar()
+		return
+	}
+
+	area := lay.gaps.area(lay.geom)
+	x, y, w, h := area.X(), area.Y(), area.Width(), area.Height()
+	barh := lay.barHeight() + lay.gaps.Inner
+	if barh >= h || !lay.createBar() {
+		// There's no room for the clients if we show the tabs, or the tabs
+		// couldn't be created, so don't show them.
+		barh = 0
+		lay.destroyBar()
+	} else {
+		// The bar is treated like a cell, so it lines up with the clients.
+		lay.bar.MoveResize(lay.gaps.cell(x, y, w, barh))
+		lay.drawTabs()
+		lay.bar.Map()
+	}
+
+	for _, c := range lay.clients {
+		lay.gaps.tile(c, x, y+barh, w, h-barh)
+	}
+}
+
+func (lay *tabbed) Unplace() {
+	lay.destroyBar()
+}
+
+func (lay *tabbed) Destroy() {
+	lay.destroyBar()
+}
+
+// createBar creates the bar and any missing tabs, and returns false if that
+// fails.
+func (lay *tabbed) createBar() bool {
+	if lay.bar == nil {
+		bar, err := xwindow.Create(lay.X, lay.X.RootWin())
+		if err != nil {
+			logger.Warning.Printf("Could not create the tab bar: %s", err)
+			return false
+		}
+		bar.Change(xproto.CwOverrideRedirect, 1)
+		bar.Change(xproto.CwBackPixel, lay.theme.ITabColor.Uint32())
+		lay.bar = bar
+	}
+	for i, c := range lay.clients {
+		if lay.tabs[i] != nil {
+			continue
+		}
+		t, err := lay.newTab(c)
+		if err != nil {
+			logger.Warning.Printf("Could not create a tab: %s", err)
+			return false
+		}
+		lay.tabs[i] = t
+	}
+	return true
+}
+
+// destroyBar destroys the bar and every tab, if they exist.
+func (lay *tabbed) destroyBar() {
+	for i, t := range lay.tabs {
+		if t != nil {
+			t.destroy()
+			lay.tabs[i] = nil
+		}
+	}
+	if lay.bar != nil {
+		lay.bar.Destroy()
+		lay.bar = nil
+	}
+}
+
+func (lay *tabbed) Exists(c Client) bool {
+	return lay.index(c) > -1
+}
+
+func (lay *tabbed) Add(c Client) {
+	if lay.Exists(c) {
+		return
+	}
+	lay.clients = append(lay.clients, c)
+	lay.tabs = append(lay.tabs, nil)
+
+	// New clients are focused and raised when they're mapped, so the new
+	// client is also the current one.
+	lay.cur = len(lay.clients) - 1
+}
+
+func (lay *tabbed) Remove(c Client) {
+	i := lay.index(c)
+	if i == -1 {
+		return
+	}
+	if lay.tabs[i] != nil {
+		lay.tabs[i].destroy()
+	}
+	lay.clients = append(lay.clients[:i], lay.clients[i+1:]...)
+	lay.tabs = append(lay.tabs[:i], lay.tabs[i+1:]...)
+
+	if lay.cur >= i && lay.cur > 0 {
+		lay.cur--
+	}
+}
+
+// Redecorate redraws the tabs when a client's name or focus state changes.
+// If the client has just been focused, it becomes the current tab.
+func (lay *tabbed) Redecorate(c Client) {
+	i := lay.index(c)
+	if i == -1 {
+		return
+	}
+	if c.IsActive() {
+		lay.cur = i
+	}
+	if lay.bar != nil {
+		lay.drawTabs()
+	}
+}
+
+func (lay *tabbed) ResizeMaster(amount float64) {}
+
+func (lay *tabbed) ResizeWindow(amount float64) {}
+
+func (lay *tabbed) Next() {
+	if len(lay.clients) > 0 {
+		lay.show((lay.cur + 1) % len(lay.clients))
+	}
+}
+
+func (lay *tabbed) Prev() {
+	if len(lay.clients) > 0 {
+		lay.show((lay.cur - 1 + len(lay.clients)) % len(lay.clients))
+	}
+}
+
+func (lay *tabbed) SwitchNext() {
+	if len(lay.clients) > 0 {
+		lay.switchClients(lay.cur, (lay.cur+1)%len(lay.clients))
+	}
+}
+
+func (lay *tabbed) SwitchPrev() {
+	if n := len(lay.clients); n > 0 {
+		lay.switchClients(lay.cur, (lay.cur-1+n)%n)
+	}
+}
+
+func (lay *tabbed) FocusMaster() {
+	if len(lay.clients) > 0 {
+		lay.show(0)
+	}
+}
+
+func (lay *tabbed) MakeMaster() {
+	if len(lay.clients) > 0 {
+		lay.switchClients(lay.cur, 0)
+	}
+}
+
+func (lay *tabbed) MastersMore() {}
+
+func (lay *tabbed) MastersFewer() {}
+
+// show makes the client at index i the current client, and brings it to
+// the front.
+func (lay *tabbed) show(i int) {
+	lay.cur = i
+	c := lay.clients[i]
+	c.Focus()
+	c.Raise()
+	lay.Place()
+}
+
+// switchClients swaps the positions of the tabs at i and j. The current tab
+// follows its client.
+func (lay *tabbed) switchClients(i, j int) {
+	if i == j {
+		return
+	}
+	lay.clients[i], lay.clients[j] = lay.clients[j], lay.clients[i]
+	lay.tabs[i], lay.tabs[j] = lay.tabs[j], lay.tabs[i]
+	switch lay.cur {
+	case i:
+		lay.cur = j
+	case j:
+		lay.cur = i
+	}
+	lay.Place()
+}
+
+func (lay *tabbed) barHeight() int {
+	if lay.stacked {
+		return len(lay.clients) * lay.theme.TabSize
+	}
+	return lay.theme.TabSize
+}
+
+// barWidth returns the width of the bar, which is the width of the workspace
+// minus the gaps.
+func (lay *tabbed) barWidth() int {
+	area := lay.gaps.area(lay.geom)
+	_, _, w, _ := lay.gaps.cell(area.X(), area.Y(), area.Width(), 1)
+	return w
+}
+
+// drawTabs positions every tab inside the bar and paints it.
+func (lay *tabbed) drawTabs() {
+	n, w, size := len(lay.clients), lay.barWidth(), lay.theme.TabSize
+	for i, t := range lay.tabs {
+		if t == nil {
+			// A client was added since the layout was last placed.
+			continue
+		}
+		var tx, ty, tw, th int
+		if lay.stacked {
+			tx, ty, tw, th = 0, i*size, w, size
+		} else {
+			// Compute the edges of each tab from the total width so that
+			// rounding errors don't leave gaps between tabs.
+			tx, ty, tw, th = i*w/n, 0, (i+1)*w/n-i*w/n, size
+		}
+		t.win.MoveResize(tx, ty, tw, th)
+		lay.paintTab(t, lay.clients[i].String(), i == lay.cur, tw, th)
+		t.win.Map()
+	}
+}
+
+func (lay *tabbed) newTab(c Client) (*tab, error) {
+	win, err := xwindow.Create(lay.X, lay.bar.Id)
+	if err != nil {
+		return nil, err
+	}
+	t := &tab{win: win}
+
+	err = mousebind.ButtonReleaseFun(
+		func(X *xgbutil.XUtil, ev xevent.ButtonReleaseEvent) {
+			if i := lay.index(c); i > -1 {
+				lay.show(i)
+			}
+		}).Connect(lay.X, t.win.Id, "1", false, true)
+	if err != nil {
+		logger.Warning.Printf("Could not bind tab clicks: %s", err)
+	}
+	return t, nil
+}
+
+// paintTab renders the tab with the given title and sets the result as the
+// tab window's background, so that the X server takes care of exposures.
+func (lay *tabbed) paintTab(t *tab, title string, active bool,
+	width, height int) {
+
+	bgColor, fontColor := lay.theme.ITabColor, lay.theme.IFontColor
+	if active {
+		bgColor, fontColor = lay.theme.ATabColor, lay.theme.AFontColor
+	}
+
+	borders := render.BorderRight
+	if lay.stacked {
+		borders = render.BorderBottom
+	}
+	img := render.NewBorder(lay.X, borders, lay.theme.BorderColor, bgColor,
+		width, height, render.GradientVert, render.GradientRegular)
+
+	// The text is clipped by the image if the title is too long.
+	if len(title) == 0 {
+		title = " "
+	}
+	_, eh := xgraphics.Extents(lay.theme.Font, lay.theme.FontSize, title)
+	_, _, err := img.Text(5, (height-eh)/2, fontColor.ImageColor(),
+		lay.theme.FontSize, lay.theme.Font, title)
+	if err != nil {
+		logger.Warning.Printf("Could not draw tab title '%s': %s", title, err)
+	}
+
+	if t.pixmap > 0 {
+		xgraphics.FreePixmap(lay.X, t.pixmap)
+	}
+	img.CreatePixmap()
+	img.XDraw()
+	t.pixmap = img.Pixmap
+
+	t.win.Change(xproto.CwBackPixmap, uint32(t.pixmap))
+	t.win.ClearAll()
+}
+
+func (t *tab) destroy() {
+	t.win.Destroy() // detaches all event handlers
+	if t.pixmap > 0 {
+		xgraphics.FreePixmap(t.win.X, t.pixmap)
+	}
+}
+
+func (lay *tabbed) index(c Client) int {
+	for i, client := range lay.clients {
+		if client == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func (lay *tabbed) MROpt(c Client, flags, x, y, width, height int) {}
+
+func (lay *tabbed) MoveResize(c Client, x, y, width, height int) {}
+
+func (lay *tabbed) Move(c Client, x, y int) {}
+
+func (lay *tabbed) Resize(c Client, width, height int) {}
@@ -27,10 +27,11 @@ func (p1 proportion) equal(p2 proportion) bool {
 
 type tree struct {
 	child node
+	gaps  Gaps
 }
 
 type node interface {
-	MoveResize(x, y, width, height int)
+	MoveResize(x, y, width, height int, gaps Gaps)
 	Proportion() proportion
 	SetProportion(p proportion)
 	Parent() node
@@ -42,7 +43,9 @@ type node interface {
 type splitter interface {
 	node
 	AddNode(n node, last bool)
+	InsertNode(n node, i int)
 	RemoveNode(n node)
+	ReplaceNode(old, new node)
 	SetChildProportion(n node, newProp proportion)
 	Size() int
 	Child(i int) node
@@ -85,11 +88,15 @@ func (t *tree) place(geom xrect.Rect) bool {
 		return false
 	}
 
+	// Every leaf loses the inner gap from its width and height, so make sure
+	// there's room for it.
+	geom = t.gaps.area(geom)
 	x, y, w, h := geom.X(), geom.Y(), geom.Width(), geom.Height()
-	if !t.child.ValidDims(w, h, 1, 1, w, h) {
+	min := 1 + t.gaps.Inner
+	if !t.child.ValidDims(w, h, min, min, w, h) {
 		return false
 	}
-	t.child.MoveResize(x, y, w, h)
+	t.child.MoveResize(x, y, w, h, t.gaps)
 	return true
 }
 
@@ -182,6 +189,16 @@ func (s *split) VisitLeafNodes(f func(lf *leaf) bool) bool {
 }
 
 func (s *split) AddNode(n node, last bool) {
+	if last {
+		s.InsertNode(n, len(s.children))
+	} else {
+		s.InsertNode(n, 0)
+	}
+}
+
+// InsertNode adds n to the split such that it ends up at index i. If i is
+// out of range, it is clamped to the beginning or end of the split.
+func (s *split) InsertNode(n node, i int) {
 	// Get the proportion of the new leaf.
 	newProp := fullPortion / proportion(len(s.children)+1)
 
@@ -196,10 +213,14 @@ func (s *split) AddNode(n node, last bool) {
 
 	n.SetProportion(newProp)
 
-	if last {
-		s.children = append(s.children, n)
-	} else {
+	switch {
+	case i <= 0:
 		s.children = append([]node{n}, s.children...)
+	case i >= len(s.children):
+		s.children = append(s.children, n)
+	default:
+		s.children = append(s.children[:i],
+			append([]node{n}, s.children[i:]...)...)
 	}
 
 	s.checkPortions()
@@ -215,7 +236,7 @@ func (s *split) RemoveNode(n node) {
 		}
 	}
 	if !removed {
-		panic(fmt.Sprintf("The node '%s' is not in the split '%s'.", n, s))
+		panic(fmt.Sprintf("The node '%v' is not in the split '%v'.", n, s))
 	}
 
 	// Distribute this node's portion to the rest.
@@ -236,6 +257,18 @@ func (s *split) RemoveNode(n node) {
 	}
 }
 
+// ReplaceNode puts new in the place of old. The new node inherits the
+// proportion of the old node, so the proportions of its siblings are
+// unaffected.
+func (s *split) ReplaceNode(old, new node) {
+	i := s.ChildIndex(old)
+	if i == -1 {
+		panic(fmt.Sprintf("The node '%v' is not in the split '%v'.", old, s))
+	}
+	new.SetProportion(old.Proportion())
+	s.children[i] = new
+}
+
 func (s *split) SetChildProportion(n node, newProp proportion) {
 	// Find the difference between the old proportion and the new. Then
 	// spread the difference over the node's siblings.
@@ -300,13 +333,13 @@ func (s *split) PropsClear() {
 	s.saved = s.saved[:0]
 }
 
-func (hs *hsplit) MoveResize(x, y, width, height int) {
+func (hs *hsplit) MoveResize(x, y, width, height int, gaps Gaps) {
 	// In hsplits, y and height remain constant. Width varies based on the
 	// proportion, and x is derived from width.
 	nextx := x
 	for _, child := range hs.children {
 		w := child.Proportion().portion(width)
-		child.MoveResize(nextx, y, w, height)
+		child.MoveResize(nextx, y, w, height, gaps)
 		nextx += w
 	}
 }
@@ -321,13 +354,13 @@ func (hs *hsplit) ValidDims(w, h, minw, minh, maxw, maxh int) bool {
 	return true
 }
 
-func (vs *vsplit) MoveResize(x, y, width, height int) {
+func (vs *vsplit) MoveResize(x, y, width, height int, gaps Gaps) {
 	// In vsplits, x and width remain constant. Height varies based on the
 	// proportion, and y is derived from height.
 	nexty := y
 	for _, child := range vs.children {
 		h := child.Proportion().portion(height)
-		child.MoveResize(x, nexty, width, h)
+		child.MoveResize(x, nexty, width, h, gaps)
 		nexty += h
 	}
 }
@@ -342,9 +375,8 @@ func (vs *vsplit) ValidDims(w, h, minw, minh, maxw, maxh int) bool {
 	return true
 }
 
-func (lf *leaf) MoveResize(x, y, width, height int) {
-	lf.client.FrameTile()
-	lf.client.MoveResize(x, y, width, height)
+func (lf *leaf) MoveResize(x, y, width, height int, gaps Gaps) {
+	gaps.tile(lf.client, x, y, width, height)
 }
 
 func (lf *leaf) Proportion() proportion {
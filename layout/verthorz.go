@@ -72,6 +72,10 @@ func (lay *verthorz) SetGeom(geom xrect.Rect) {
 	lay.geom = geom
 }
 
+func (lay *verthorz) SetGaps(gaps Gaps) {
+	lay.store.gaps = gaps
+}
+
 func (lay verthorz) Place() {
 	lay.store.place(lay.geom)
 }
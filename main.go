@@ -14,6 +14,8 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/BurntSushi/gribble"
+
 	"github.com/BurntSushi/xgb"
 	"github.com/BurntSushi/xgb/xproto"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/BurntSushi/wingo/hook"
 	"github.com/BurntSushi/wingo/logger"
 	"github.com/BurntSushi/wingo/misc"
+	"github.com/BurntSushi/wingo/rules"
 	"github.com/BurntSushi/wingo/stack"
 	"github.com/BurntSushi/wingo/wm"
 	"github.com/BurntSushi/wingo/xclient"
@@ -143,7 +146,9 @@ func main() {
 	stack.Initialize(X)
 	cursors.Initialize(X)
 	wm.Initialize(X, commands.Env, newHacks())
-	hook.Initialize(commands.Env, misc.ConfigFile("hooks.wini"))
+	hook.Initialize(commands.Env, commands.Exec, commands.Inline,
+		misc.ConfigFile("hooks.wini"))
+	rules.Initialize(misc.ConfigFile("rules.wini"))
 
 	// Initialize event handlers on the root window.
 	rootInit(X)
@@ -155,7 +160,12 @@ func main() {
 	go ipc(X)
 
 	// And start up the IPC event notifier.
-	go event.Notifier(X, socketFilePath(X))
+	go event.Notifier(X, socketFilePath(X), func(f func()) {
+		commands.Exec(func() gribble.Value {
+			f()
+			return nil
+		})
+	})
 
 	// Just before starting the main event loop, check to see if there are
 	// any clients that already exist that we should manage.
@@ -174,6 +184,7 @@ func main() {
 	wm.FocusFallback()
 	wm.Startup = false
 	pingBefore, pingAfter, pingQuit := xevent.MainPing(X)
+	commands.SetEventLoop(pingBefore, pingAfter)
 
 	if len(flagCpuProfile) > 0 {
 		f, err := os.Create(flagCpuProfile)
@@ -189,6 +200,11 @@ func main() {
 	} else {
 		hook.Fire(hook.Startup, hook.Args{})
 	}
+	if wm.IdleExt {
+		hook.StartTimers(wm.IdleTime)
+	} else {
+		hook.StartTimers(nil)
+	}
 
 EVENTLOOP:
 	for {
@@ -196,12 +212,26 @@ EVENTLOOP:
 		case <-pingBefore:
 			// Wait for the event to finish processing.
 			<-pingAfter
-		case f := <-commands.SafeExec:
-			commands.SafeReturn <- f()
+		case job := <-commands.Jobs:
+			job()
 		case <-pingQuit:
 			break EVENTLOOP
 		}
 	}
+	if !wm.Restart {
+		// The main event loop has stopped, so the jobs started by the quit
+		// hooks must be run here.
+		quitHooks := hook.Fire(hook.Quit, hook.Args{})
+	QUITHOOKS:
+		for {
+			select {
+			case job := <-commands.Jobs:
+				job()
+			case <-quitHooks:
+				break QUITHOOKS
+			}
+		}
+	}
 	if wm.Restart {
 		event.Notify(event.Restarting{})
 		for _, client := range wm.Clients {
@@ -226,7 +256,7 @@ EVENTLOOP:
 		if !found {
 			os.Args = append(os.Args, "--wingo-restarted")
 		}
-		logger.Message.Println("The user has told us to restart...\n\n\n")
+		logger.Message.Printf("The user has told us to restart...\n\n\n")
 		execPath, err := os.Executable()
 		if err != nil {
 			logger.Warning.Printf("could not find executable: %s\n", err)
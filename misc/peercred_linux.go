@@ -0,0 +1,39 @@
+package misc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+// CheckPeer returns an error unless the process on the other end of conn is
+// run by the same user as Wingo. conn must be a unix domain socket.
+func CheckPeer(conn net.Conn) error {
+	uconn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return fmt.Errorf("%s is not a unix domain socket", conn.RemoteAddr())
+	}
+	raw, err := uconn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var cred *syscall.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd),
+			syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return err
+	}
+	if credErr != nil {
+		return fmt.Errorf("could not get peer credentials: %s", credErr)
+	}
+	if int(cred.Uid) != os.Getuid() {
+		return fmt.Errorf("peer (pid %d) is run by uid %d, not %d",
+			cred.Pid, cred.Uid, os.Getuid())
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package misc
+
+import (
+	"net"
+)
+
+// CheckPeer returns an error unless the process on the other end of conn is
+// run by the same user as Wingo. Peer credentials can only be checked on
+// Linux, so everywhere else, only the permissions of the socket keep other
+// users out.
+func CheckPeer(conn net.Conn) error {
+	return nil
+}
@@ -0,0 +1,14 @@
+/*
+package rules reads the rules in Wingo's rules.wini configuration file, and
+finds the rules that match a client being managed.
+
+A rule sets properties of a client, like the workspace it should go to or
+whether it should float. Unlike hooks, rules are applied while a client is
+being managed, before it is placed and mapped. This package only reads rules
+and decides which ones match; it's up to the xclient package to apply them.
+
+Please see config/rules.wini in the Wingo project directory for an
+explanation of how rules can be specified:
+https://github.com/BurntSushi/wingo/blob/master/config/rules.wini
+*/
+package rules
@@ -0,0 +1,322 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/xgbutil/xrect"
+
+	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/wini"
+)
+
+// Toggle is a property that a rule may turn on or off, or leave alone.
+type Toggle int
+
+const (
+	Unset Toggle = iota
+	On
+	Off
+)
+
+// Window describes a client being managed. It's everything that a rule can
+// match against.
+type Window struct {
+	Class     string
+	Instance  string
+	Role      string
+	Name      string
+	Types     []string // EWMH window types, like _NET_WM_WINDOW_TYPE_DIALOG
+	Transient bool
+}
+
+// Props are the properties that rules can set on a client. The zero value of
+// each property (or -1 for Head and Opacity) means that no rule set it.
+type Props struct {
+	// The name of the workspace the client should go to.
+	Workspace string
+
+	// The index of the head whose visible workspace the client should go to.
+	// Workspace takes precedence.
+	Head int
+
+	// The geometry of the client's frame, relative to its workspace.
+	Geometry xrect.Rect
+
+	// Off tiles clients that would otherwise be forced to float, like
+	// dialogs.
+	Floating Toggle
+	Sticky   Toggle
+	Focus    Toggle
+
+	// One of "full", "borders", "slim" or "nada".
+	Frame string
+
+	// One of "above", "default" or "below".
+	Layer string
+
+	// Between 0 and 1.
+	Opacity float64
+}
+
+type rule struct {
+	// From the config file. Nice for error messages.
+	name string
+
+	// Each criterion matches if the window matches any of its values.
+	// An empty criterion always matches.
+	classes   []string
+	instances []string
+	roles     []string
+	titles    []*regexp.Regexp
+	types     []string
+	transient Toggle
+
+	props Props
+}
+
+// All rules, in the order they appear in the config file.
+var rules []rule
+
+// Initialize reads the rules in the wini formatted file at fpath. If that
+// fails, only a warning is logged since rules are not essential for Wingo to
+// run.
+func Initialize(fpath string) {
+	cdata, err := wini.Parse(fpath)
+	if err != nil {
+		logger.Warning.Printf("Could not parse '%s': %s", fpath, err)
+		return
+	}
+	for _, name := range cdata.Sections() {
+		r, err := readSection(cdata, name)
+		if err != nil {
+			logger.Warning.Printf("Could not load rule '%s': %s", name, err)
+			continue
+		}
+		rules = append(rules, r)
+	}
+}
+
+// Find returns the properties set by every rule that matches win. If more
+// than one rule sets the same property, the rule that comes last in the
+// config file wins.
+func Find(win Window) Props {
+	props := Props{Head: -1, Opacity: -1}
+	for _, r := range rules {
+		if !r.matches(win) {
+			continue
+		}
+		logger.Lots.Printf("The rule '%s' matches '%s'.", r.name, win.Name)
+		props.merge(r.props)
+	}
+	return props
+}
+
+// merge sets every property in props that is set in other.
+func (props *Props) merge(other Props) {
+	if len(other.Workspace) > 0 {
+		props.Workspace = other.Workspace
+	}
+	if other.Head > -1 {
+		props.Head = other.Head
+	}
+	if other.Geometry != nil {
+		props.Geometry = other.Geometry
+	}
+	if other.Floating != Unset {
+		props.Floating = other.Floating
+	}
+	if other.Sticky != Unset {
+		props.Sticky = other.Sticky
+	}
+	if other.Focus != Unset {
+		props.Focus = other.Focus
+	}
+	if len(other.Frame) > 0 {
+		props.Frame = other.Frame
+	}
+	if len(other.Layer) > 0 {
+		props.Layer = other.Layer
+	}
+	if other.Opacity > -1 {
+		props.Opacity = other.Opacity
+	}
+}
+
+func (r rule) matches(win Window) bool {
+	if !matchString(r.classes, win.Class) ||
+		!matchString(r.instances, win.Instance) ||
+		!matchString(r.roles, win.Role) {
+
+		return false
+	}
+	if len(r.titles) > 0 {
+		matched := false
+		for _, title := range r.titles {
+			if title.MatchString(win.Name) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(r.types) > 0 {
+		matched := false
+		for _, typ := range r.types {
+			for _, winType := range win.Types {
+				if typ == winType {
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	switch r.transient {
+	case On:
+		return win.Transient
+	case Off:
+		return !win.Transient
+	}
+	return true
+}
+
+// matchString returns true if s is one of vals, or if vals is empty.
+func matchString(vals []string, s string) bool {
+	if len(vals) == 0 {
+		return true
+	}
+	for _, val := range vals {
+		if val == s {
+			return true
+		}
+	}
+	return false
+}
+
+// readSection reads the rule in a section of the config file. Every key is
+// checked, so that mistakes are found when Wingo starts instead of when a
+// client is managed.
+func readSection(cdata *wini.Data, section string) (rule, error) {
+	r := rule{name: section, props: Props{Head: -1, Opacity: -1}}
+	criteria := 0
+	for _, key := range cdata.Keys(section) {
+		var err error
+		vals := key.Strings()
+		last := vals[len(vals)-1]
+
+		switch key.Name() {
+		case "class":
+			r.classes, criteria = vals, criteria+1
+		case "instance":
+			r.instances, criteria = vals, criteria+1
+		case "role":
+			r.roles, criteria = vals, criteria+1
+		case "title":
+			for _, val := range vals {
+				re, err := regexp.Compile(val)
+				if err != nil {
+					return rule{}, key.Err("Bad regular expression: %s", err)
+				}
+				r.titles = append(r.titles, re)
+			}
+			criteria++
+		case "type":
+			for _, val := range vals {
+				r.types = append(r.types, "_NET_WM_WINDOW_TYPE_"+
+					strings.ToUpper(val))
+			}
+			criteria++
+		case "transient":
+			r.transient, err = readToggle(key)
+			criteria++
+		case "workspace":
+			r.props.Workspace = last
+		case "head":
+			var heads []int
+			if heads, err = key.Ints(); err == nil {
+				r.props.Head = heads[len(heads)-1]
+				if r.props.Head < 0 {
+					err = key.Err("Head indices start at 0.")
+				}
+			}
+		case "geometry":
+			r.props.Geometry, err = readGeometry(key, last)
+		case "floating":
+			r.props.Floating, err = readToggle(key)
+		case "sticky":
+			r.props.Sticky, err = readToggle(key)
+		case "focus":
+			r.props.Focus, err = readToggle(key)
+		case "frame":
+			r.props.Frame = strings.ToLower(last)
+			switch r.props.Frame {
+			case "full", "borders", "slim", "nada":
+			default:
+				err = key.Err("Unknown frame '%s'.", last)
+			}
+		case "layer":
+			r.props.Layer = strings.ToLower(last)
+			switch r.props.Layer {
+			case "above", "default", "below":
+			default:
+				err = key.Err("Unknown layer '%s'.", last)
+			}
+		case "opacity":
+			var opacities []float64
+			if opacities, err = key.Floats(); err == nil {
+				r.props.Opacity = opacities[len(opacities)-1]
+				if r.props.Opacity < 0 || r.props.Opacity > 1 {
+					err = key.Err("Opacity must be in the range [0, 1].")
+				}
+			}
+		default:
+			err = fmt.Errorf("Unrecognized option '%s'.", key.Name())
+		}
+		if err != nil {
+			return rule{}, err
+		}
+	}
+	if criteria == 0 {
+		// A rule with no criteria would match every client, which is almost
+		// certainly a mistake.
+		return rule{}, fmt.Errorf("No match criteria were found.")
+	}
+	return r, nil
+}
+
+func readToggle(key wini.Key) (Toggle, error) {
+	vals, err := key.Bools()
+	if err != nil {
+		return Unset, err
+	}
+	if vals[len(vals)-1] {
+		return On, nil
+	}
+	return Off, nil
+}
+
+// readGeometry reads a geometry written as "x y width height".
+func readGeometry(key wini.Key, val string) (xrect.Rect, error) {
+	fields := strings.Fields(val)
+	if len(fields) != 4 {
+		return nil, key.Err("Expected 'x y width height' but got '%s'.", val)
+	}
+	var nums [4]int
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, key.Err("'%s' is not an integer.", field)
+		}
+		nums[i] = n
+	}
+	if nums[2] <= 0 || nums[3] <= 0 {
+		return nil, key.Err("The width and height must be positive.")
+	}
+	return xrect.New(nums[0], nums[1], nums[2], nums[3]), nil
+}
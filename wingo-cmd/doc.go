@@ -5,6 +5,7 @@ commands.
 
 Usage:
 	wingo-cmd [flags] [command]
+	wingo-cmd --subscribe [flags]
 
 Note that 'command' MUST be specified as a single argument. So that this is
 illegal:
@@ -32,5 +33,40 @@ The flags are:
 	--poll milliseconds
 		When milliseconds is greater than 0, the given commands will be
 		executed at the specified interval.
+	--json
+		Send the commands with Wingo's JSON protocol and print the JSON
+		response, which holds either a typed result or a structured error.
+		With --subscribe, print each event as JSON.
+	--wait-for condition
+		Wait for a new client to be managed for which the match condition
+		returns 1, where ":client:" in the condition is replaced with the id
+		of each new client. Then print the id of the client, or if a command
+		was given, run it with ":client:" replaced with the id of the client.
+		For example, to send Firefox to the "www" workspace once it starts:
+
+			wingo-cmd --wait-for 'MatchClientClass ":client:" "Firefox"' \
+				'WorkspaceSendClient "www" ":client:"' &
+			firefox &
+
+		Start wingo-cmd before the program, since only clients that are
+		managed after it starts waiting are considered.
+	--timeout milliseconds
+		When greater than 0, --wait-for gives up and exits with an error
+		after the given number of milliseconds.
+	--subscribe
+		Instead of sending a command, print events as Wingo sends them,
+		one per line, until Wingo quits.
+	--events names
+		A comma separated list of the names of the events that --subscribe
+		prints, e.g., "FocusedClient,ChangedWorkspace".
+	--on EventName=command
+		Run the shell command whenever --subscribe gets an event named
+		EventName, or any event if EventName is "*". Each field of the event
+		is in the environment of the command, with its name in upper case
+		and prefixed with "WINGO_". For example:
+
+			wingo-cmd --subscribe --on 'FocusedClient=echo $WINGO_NAME'
+
+		This flag may be given more than once.
 */
 package main
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -20,18 +21,28 @@ import (
 )
 
 var (
+	flagEvents            = ""
 	flagFileInput         = ""
+	flagJSON              = false
 	flagListCommands      = false
 	flagListTypeCommands  = false
 	flagListUsageCommands = false
+	flagOn                = make(eventHooks)
 	flagPoll              = 0
+	flagSubscribe         = false
+	flagTimeout           = 0
 	flagUsageCommand      = ""
+	flagWaitFor           = ""
 )
 
 func init() {
 	flag.StringVar(&flagFileInput, "f", flagFileInput,
 		"When set, commands will be read from the specified file.\n"+
 			"If '-' is used, commands will be read from stdin.")
+	flag.BoolVar(&flagJSON, "json", flagJSON,
+		"When set, commands are sent with Wingo's JSON protocol, and the\n"+
+			"JSON response is printed. With --subscribe, events are printed\n"+
+			"as JSON.")
 	flag.BoolVar(&flagListCommands, "list", flagListCommands,
 		"Print a list of all commands and their parameters.")
 	flag.BoolVar(&flagListTypeCommands, "list-types", flagListTypeCommands,
@@ -44,6 +55,26 @@ func init() {
 	flag.IntVar(&flagPoll, "poll", flagPoll,
 		"When greater than 0, the commands specified will be repeated at "+
 			"the interval specified in milliseconds.")
+	flag.BoolVar(&flagSubscribe, "subscribe", flagSubscribe,
+		"When set, no command is sent. Instead, events are printed as\n"+
+			"Wingo sends them.")
+	flag.StringVar(&flagEvents, "events", flagEvents,
+		"A comma separated list of the names of the events to print with\n"+
+			"--subscribe. All events are printed by default.")
+	flag.StringVar(&flagWaitFor, "wait-for", flagWaitFor,
+		"When set, wait for a new client for which this match condition\n"+
+			"is true, e.g., 'MatchClientClass \":client:\" \"Firefox\"'.\n"+
+			"The id of the client is printed, unless a command is given,\n"+
+			"in which case \":client:\" is replaced with the id in the\n"+
+			"command, and it is run.")
+	flag.IntVar(&flagTimeout, "timeout", flagTimeout,
+		"When greater than 0, --wait-for gives up with an error after\n"+
+			"this many milliseconds.")
+	flag.Var(flagOn, "on",
+		"'EventName=command' runs the shell command whenever an event\n"+
+			"named EventName is received with --subscribe. The fields of\n"+
+			"the event are in the environment, e.g., $WINGO_ID. Use '*'\n"+
+			"to match every event. May be given more than once.")
 
 	flag.Usage = usage
 	flag.Parse()
@@ -55,6 +86,18 @@ func main() {
 	// If a list/usage flag is set, print the stuff and exit.
 	handleFlags()
 
+	// Print events instead of sending commands.
+	if flagSubscribe {
+		subscribe()
+		return
+	}
+
+	// Wait for a client before running any commands.
+	if len(flagWaitFor) > 0 {
+		waitFor()
+		return
+	}
+
 	// Get the commands from file/stdin/argument.
 	cmds := getCommands()
 
@@ -64,23 +107,28 @@ func main() {
 		log.Fatalf("Could not connect to Wingo IPC: %s", err)
 	}
 
+	reader := bufio.NewReader(conn)
+	if flagJSON {
+		send(conn, "PROTOCOL json")
+		recv(reader)
+	}
+
 	// If the 'poll' flag is set, then we'll need to send the command and
 	// print the result repeatedly.
-	for {
-		// Send the command.
-		if _, err = fmt.Fprintf(conn, "%s%c", cmds, 0); err != nil {
-			log.Fatalf("Error writing command: %s", err)
-		}
-
-		// Read the response.
-		reader := bufio.NewReader(conn)
-		msg, err := reader.ReadString(0)
-		if err != nil {
-			log.Fatalf("Could not read response: %s", err)
+	for id := 1; ; id++ {
+		if flagJSON {
+			req, err := json.Marshal(map[string]interface{}{
+				"id":      id,
+				"command": cmds,
+			})
+			if err != nil {
+				log.Fatalf("Could not encode command: %s", err)
+			}
+			send(conn, string(req))
+		} else {
+			send(conn, cmds)
 		}
-		msg = msg[:len(msg)-1] // get rid of null terminator
-
-		fmt.Println(msg)
+		fmt.Println(recv(reader))
 
 		if flagPoll == 0 {
 			break
@@ -90,6 +138,22 @@ func main() {
 	}
 }
 
+// send writes a null terminated message to the command server.
+func send(conn net.Conn, msg string) {
+	if _, err := fmt.Fprintf(conn, "%s%c", msg, 0); err != nil {
+		log.Fatalf("Error writing command: %s", err)
+	}
+}
+
+// recv reads a null terminated message from the command server.
+func recv(reader *bufio.Reader) string {
+	msg, err := reader.ReadString(0)
+	if err != nil {
+		log.Fatalf("Could not read response: %s", err)
+	}
+	return msg[:len(msg)-1] // get rid of null terminator
+}
+
 func socketFilePath() string {
 	c := cmd.New("wingo", "--show-socket")
 	if err := c.Run(); err != nil {
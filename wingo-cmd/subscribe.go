@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// eventHooks maps event names to the shell commands that are run whenever
+// an event with that name is received. The special name "*" matches every
+// event. It satisfies flag.Value so that '-on' can be given more than once.
+type eventHooks map[string][]string
+
+func (hooks eventHooks) String() string {
+	return ""
+}
+
+func (hooks eventHooks) Set(s string) error {
+	pieces := strings.SplitN(s, "=", 2)
+	if len(pieces) != 2 || len(pieces[0]) == 0 || len(pieces[1]) == 0 {
+		return fmt.Errorf("expected 'EventName=command' but got '%s'", s)
+	}
+	name, command := strings.TrimSpace(pieces[0]), pieces[1]
+	hooks[name] = append(hooks[name], command)
+	return nil
+}
+
+// subscribe connects to Wingo's event socket and prints every event it gets
+// until the connection is closed, running any hooks set with '-on'.
+func subscribe() {
+	conn, err := net.Dial("unix", socketFilePath()+"-notify")
+	if err != nil {
+		log.Fatalf("Could not connect to Wingo event IPC: %s", err)
+	}
+	defer conn.Close()
+
+	if len(flagEvents) > 0 {
+		filter, err := json.Marshal(map[string][]string{
+			"Events": strings.Split(flagEvents, ","),
+		})
+		if err != nil {
+			log.Fatalf("Could not encode event filter: %s", err)
+		}
+		send(conn, string(filter))
+	}
+
+	reader := bufio.NewReader(conn)
+	for {
+		msg, err := reader.ReadString(0)
+		if err != nil {
+			log.Fatalf("Could not read event: %s", err)
+		}
+		msg = msg[:len(msg)-1] // get rid of null terminator
+
+		ev := make(map[string]interface{})
+		decoder := json.NewDecoder(strings.NewReader(msg))
+		decoder.UseNumber()
+		if err := decoder.Decode(&ev); err != nil {
+			log.Printf("Could not decode event: %s", err)
+			continue
+		}
+
+		// Keep-alives and subscription acknowledgements aren't interesting.
+		name, _ := ev["EventName"].(string)
+		if name == "Noop" || name == "Subscribed" {
+			continue
+		}
+
+		if flagJSON {
+			fmt.Println(strings.TrimSpace(msg))
+		} else {
+			fmt.Println(prettyEvent(name, ev))
+		}
+		runHooks(name, ev)
+	}
+}
+
+// prettyEvent formats an event on one line, as its name followed by each of
+// its fields in alphabetical order.
+func prettyEvent(name string, ev map[string]interface{}) string {
+	keys := make([]string, 0, len(ev))
+	for key := range ev {
+		if key != "EventName" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	buf := bytes.NewBufferString(name)
+	for _, key := range keys {
+		var val string
+		if s, ok := ev[key].(string); ok {
+			val = fmt.Sprintf("%q", s)
+		} else {
+			val = fieldString(ev[key])
+		}
+		fmt.Fprintf(buf, " %s=%s", key, val)
+	}
+	return buf.String()
+}
+
+// runHooks runs every hook for the event named name, one after the other.
+// Each field of the event is in the environment of the hook, with its name
+// in upper case and prefixed with "WINGO_". e.g., WINGO_EVENTNAME or WINGO_ID.
+func runHooks(name string, ev map[string]interface{}) {
+	var cmds []string
+	cmds = append(cmds, flagOn["*"]...)
+	cmds = append(cmds, flagOn[name]...)
+	if len(cmds) == 0 {
+		return
+	}
+
+	env := os.Environ()
+	for key, val := range ev {
+		env = append(env, fmt.Sprintf("WINGO_%s=%s",
+			strings.ToUpper(key), fieldString(val)))
+	}
+	for _, command := range cmds {
+		c := exec.Command("sh", "-c", command)
+		c.Env = env
+		c.Stdout, c.Stderr = os.Stdout, os.Stderr
+		if err := c.Run(); err != nil {
+			log.Printf("Error running '%s' for %s: %s", command, name, err)
+		}
+	}
+}
+
+// fieldString returns the value of an event field as a string. Strings and
+// numbers are used as is, and anything else is written as JSON.
+func fieldString(val interface{}) string {
+	switch val := val.(type) {
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	}
+	bs, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return string(bs)
+}
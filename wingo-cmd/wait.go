@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/BurntSushi/wingo/ipc"
+)
+
+// waitFor waits for a new client for which the '--wait-for' condition is
+// true. If no command was given, the id of the client is printed. Otherwise,
+// ":client:" is replaced with the id of the client in the commands, which are
+// then run. If no client matches in time, wingo-cmd exits with an error.
+func waitFor() {
+	cmds := ""
+	if len(flagFileInput) > 0 || flag.NArg() > 0 {
+		cmds = getCommands()
+	}
+
+	c, err := ipc.Dial(socketFilePath())
+	if err != nil {
+		log.Fatalf("Could not connect to Wingo IPC: %s", err)
+	}
+	defer c.Close()
+
+	client, err := c.WaitForManaged(flagWaitFor, flagTimeout)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(cmds) == 0 {
+		fmt.Println(client)
+		return
+	}
+
+	// The quotes are replaced too, so that the id is passed as an int.
+	cmds = strings.Replace(cmds, "\":client:\"", client.String(), -1)
+	result, err := c.Run(cmds)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(result)
+}
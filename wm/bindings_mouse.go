@@ -50,7 +50,7 @@ func (mcmd mouseCommand) setup(c Client, wid xproto.Window) {
 	if mcmd.cmdName == "MouseResize" {
 		direction, err := cmdHacks.MouseResizeDirection(mcmd.cmdStr)
 		if err != nil {
-			logger.Warning.Println("Could not setup MouseResize: %s", err)
+			logger.Warning.Printf("Could not setup MouseResize: %s", err)
 			return
 		}
 		setupResizeDrag(c, wid, mcmd.buttonStr, true, strToDirection(direction))
@@ -66,7 +66,7 @@ func (mcmd mouseCommand) setup(c Client, wid xproto.Window) {
 			defer mouseClientLock.Unlock()
 
 			MouseClientClicked = c.Id()
-			_, err := gribbleEnv.Run(mcmd.cmdStr)
+			_, err := cmdHacks.Run(mcmd.cmdStr)
 			if err != nil {
 				logger.Warning.Println(err)
 			}
@@ -182,7 +182,7 @@ func rootMouseSetup() {
 		mcmd := mcmd_
 		run := func() {
 			go func() {
-				_, err := gribbleEnv.Run(mcmd.cmdStr)
+				_, err := cmdHacks.Run(mcmd.cmdStr)
 				if err != nil {
 					logger.Warning.Println(err)
 				}
@@ -3,6 +3,7 @@ package wm
 import (
 	"github.com/BurntSushi/xgb/xproto"
 
+	"github.com/BurntSushi/wingo/event"
 	"github.com/BurntSushi/wingo/frame"
 	"github.com/BurntSushi/wingo/heads"
 	"github.com/BurntSushi/wingo/prompt"
@@ -20,6 +21,7 @@ type Client interface {
 	ImminentDestruction() bool
 	IsMaximized() bool
 	Remaximize()
+	EventClient() event.Client
 
 	CycleItem() *prompt.CycleItem
 	SelectItem() *prompt.SelectItem
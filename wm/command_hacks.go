@@ -1,6 +1,14 @@
 package wm
 
+import (
+	"github.com/BurntSushi/gribble"
+)
+
 type CommandHacks struct {
 	MouseResizeDirection     func(cmdStr string) (string, error)
 	CycleClientRunWithKeyStr func(keyStr, cmdStr string) (func(), error)
+
+	// Run runs a command on the main event loop. Bindings must use it
+	// instead of running commands with the Gribble environment directly.
+	Run func(cmdStr string) (gribble.Value, error)
 }
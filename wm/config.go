@@ -5,6 +5,7 @@ import (
 
 	"github.com/BurntSushi/xgbutil/ewmh"
 
+	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/logger"
 	"github.com/BurntSushi/wingo/misc"
 	"github.com/BurntSushi/wingo/wini"
@@ -26,6 +27,16 @@ type Configuration struct {
 	ShowFyi, ShowErrors bool
 	Shell               string
 	AudioProgram        string
+	GapInner, GapOuter  int
+	FloatingPlacement   int
+	SnapDistance        int
+	EdgeResistance      int
+	EdgeTiling          bool
+	ReadOnlySocket      bool
+
+	// Per-workspace overrides of the gaps, keyed by lower-case workspace
+	// name. A value of -1 means there is no override.
+	wrkGaps map[string]*layout.Gaps
 
 	mouse map[string][]mouseCommand
 	key   map[string][]keyCommand
@@ -51,6 +62,16 @@ func newConfig() *Configuration {
 		ShowErrors:      true,
 		Shell:           "bash",
 		AudioProgram:    "aplay",
+		GapInner:        0,
+		GapOuter:        0,
+
+		FloatingPlacement: layout.PlaceSmart,
+		SnapDistance:      10,
+		EdgeResistance:    20,
+		EdgeTiling:        true,
+		ReadOnlySocket:    false,
+
+		wrkGaps: map[string]*layout.Gaps{},
 
 		mouse: map[string][]mouseCommand{},
 		key:   map[string][]keyCommand{},
@@ -81,7 +102,8 @@ func loadConfig() (*Configuration, error) {
 			misc.ConfigFile("options.wini"),
 			(*Configuration).loadOptionsConfigSection,
 		},
-		// FYI hooks.wini is loaded in the hook package.
+		// FYI hooks.wini and rules.wini are loaded in the hook and rules
+		// packages.
 	}
 	for _, cfile := range cfiles {
 		cdata, err := wini.Parse(cfile.fpath)
@@ -171,6 +193,13 @@ func (conf *Configuration) loadKeyConfigSection(
 func (conf *Configuration) loadOptionsConfigSection(
 	cdata *wini.Data, section string) {
 
+	// Sections named "Workspace NAME" hold options for a single workspace.
+	if strings.HasPrefix(section, "workspace ") {
+		conf.loadWorkspaceOptions(cdata, section,
+			strings.TrimSpace(section[len("workspace "):]))
+		return
+	}
+
 	for _, key := range cdata.Keys(section) {
 		option := key.Name()
 		switch option {
@@ -204,8 +233,62 @@ func (conf *Configuration) loadOptionsConfigSection(
 			setString(key, &conf.Shell)
 		case "audio_play_cmd":
 			setString(key, &conf.AudioProgram)
+		case "gap_inner":
+			setInt(key, &conf.GapInner)
+		case "gap_outer":
+			setInt(key, &conf.GapOuter)
+		case "snap_distance":
+			setInt(key, &conf.SnapDistance)
+		case "edge_resistance":
+			setInt(key, &conf.EdgeResistance)
+		case "edge_tiling":
+			setBool(key, &conf.EdgeTiling)
+		case "read_only_socket":
+			setBool(key, &conf.ReadOnlySocket)
+		case "floating_placement":
+			if name, ok := getLastString(key); ok {
+				if policy, ok := layout.PlacementPolicy(name); ok {
+					conf.FloatingPlacement = policy
+				} else {
+					logger.Warning.Printf(
+						"Unknown floating placement policy '%s'.", name)
+				}
+			}
+		}
+	}
+}
+
+// loadWorkspaceOptions loads the options that can be overridden for a single
+// workspace.
+func (conf *Configuration) loadWorkspaceOptions(
+	cdata *wini.Data, section, wrkName string) {
+
+	gaps := &layout.Gaps{Inner: -1, Outer: -1}
+	conf.wrkGaps[wrkName] = gaps
+	for _, key := range cdata.Keys(section) {
+		switch key.Name() {
+		case "gap_inner":
+			setInt(key, &gaps.Inner)
+		case "gap_outer":
+			setInt(key, &gaps.Outer)
+		}
+	}
+}
+
+// Gaps returns the gaps that the workspace with the given name should start
+// with. Overrides for the workspace are used if they exist, and the global
+// options are used otherwise.
+func (conf *Configuration) Gaps(wrkName string) layout.Gaps {
+	gaps := layout.Gaps{Inner: conf.GapInner, Outer: conf.GapOuter}
+	if override, ok := conf.wrkGaps[strings.ToLower(wrkName)]; ok {
+		if override.Inner >= 0 {
+			gaps.Inner = override.Inner
+		}
+		if override.Outer >= 0 {
+			gaps.Outer = override.Outer
 		}
 	}
+	return gaps
 }
 
 // strToDirection converts a string representation of a mouse direction
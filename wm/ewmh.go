@@ -9,6 +9,7 @@ import (
 	"github.com/BurntSushi/xgbutil/xwindow"
 
 	"github.com/BurntSushi/wingo/event"
+	"github.com/BurntSushi/wingo/hook"
 	"github.com/BurntSushi/wingo/workspace"
 )
 
@@ -24,20 +25,38 @@ func ewmhNumberOfDesktops() {
 	ewmh.NumberOfDesktopsSet(X, uint(len(Heads.Workspaces.Wrks)))
 }
 
+// lastWorkspace is the name of the workspace that was active the last time
+// the active workspace changed.
+var lastWorkspace string
+
 func ewmhCurrentDesktop() {
-	ewmh.CurrentDesktopSet(X, uint(workspaceIndex(Workspace())))
-	event.Notify(event.ChangedWorkspace{})
+	wrk := Workspace()
+	ewmh.CurrentDesktopSet(X, uint(workspaceIndex(wrk)))
+	event.Notify(event.ChangedWorkspace{
+		Workspace: wrk.Name,
+		Previous:  lastWorkspace,
+		Head:      Heads.VisibleIndex(wrk),
+	})
+	if wrk.Name != lastWorkspace {
+		hook.Fire(hook.WorkspaceChanged, hook.Args{
+			Workspace: wrk.Name,
+			Head:      fmt.Sprintf("%d", Heads.VisibleIndex(wrk)),
+		})
+	}
+	lastWorkspace = wrk.Name
 }
 
 func ewmhVisibleDesktops() {
 	visibles := Heads.VisibleWorkspaces()
 	desks := make([]uint, len(visibles))
+	names := make([]string, len(visibles))
 	for i, wrk := range visibles {
 		desks[i] = uint(workspaceIndex(wrk))
+		names[i] = wrk.Name
 	}
 	ewmh.VisibleDesktopsSet(X, desks)
 
-	event.Notify(event.ChangedVisibleWorkspace{})
+	event.Notify(event.ChangedVisibleWorkspace{Workspaces: names})
 }
 
 func ewmhDesktopNames() {
@@ -47,7 +66,7 @@ func ewmhDesktopNames() {
 	}
 	ewmh.DesktopNamesSet(X, names)
 
-	event.Notify(event.ChangedWorkspaceNames{})
+	event.Notify(event.ChangedWorkspaceNames{Names: names})
 }
 
 func ewmhDesktopGeometry() {
@@ -2,20 +2,24 @@ package wm
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/BurntSushi/gribble"
 
+	"github.com/BurntSushi/xgb/screensaver"
 	"github.com/BurntSushi/xgb/shape"
 	"github.com/BurntSushi/xgb/xproto"
 
 	"github.com/BurntSushi/xgbutil"
 	"github.com/BurntSushi/xgbutil/ewmh"
 	"github.com/BurntSushi/xgbutil/xevent"
+	"github.com/BurntSushi/xgbutil/xrect"
 	"github.com/BurntSushi/xgbutil/xwindow"
 
 	"github.com/BurntSushi/wingo/event"
 	"github.com/BurntSushi/wingo/focus"
 	"github.com/BurntSushi/wingo/heads"
+	"github.com/BurntSushi/wingo/hook"
 	"github.com/BurntSushi/wingo/logger"
 	"github.com/BurntSushi/wingo/workspace"
 )
@@ -33,6 +37,7 @@ var (
 	gribbleEnv *gribble.Environment
 	cmdHacks   CommandHacks
 	ShapeExt   bool
+	IdleExt    bool
 	Restart    bool
 )
 
@@ -62,7 +67,7 @@ func Initialize(x *xgbutil.XUtil,
 	Clients = make(ClientList, 0, 50)
 	Prompts = newPrompts()
 
-	Heads = heads.NewHeads(X, Config.DefaultLayout)
+	Heads = heads.NewHeads(X, Config.DefaultLayout, Theme.Full.TabTheme())
 
 	// If _NET_DESKTOP_NAMES is set, let's use workspaces from that instead.
 	if names, _ := ewmh.DesktopNamesGet(X); len(names) > 0 {
@@ -95,6 +100,15 @@ func Initialize(x *xgbutil.XUtil,
 		ShapeExt = true
 	}
 
+	err = screensaver.Init(X.Conn())
+	if err != nil {
+		IdleExt = false
+		logger.Warning.Printf("The X MIT-SCREEN-SAVER extension could not " +
+			"be loaded. Idle and active hooks will not fire.")
+	} else {
+		IdleExt = true
+	}
+
 	Restart = false
 
 	ewmhClientList()
@@ -103,6 +117,22 @@ func Initialize(x *xgbutil.XUtil,
 	ewmhVisibleDesktops()
 	ewmhDesktopNames()
 	ewmhDesktopGeometry()
+
+	event.SnapshotFun = snapshot
+}
+
+// IdleTime returns how long it has been since the user last pressed a key or
+// moved the mouse. It requires the MIT-SCREEN-SAVER extension.
+func IdleTime() (time.Duration, error) {
+	if !IdleExt {
+		return 0, fmt.Errorf("The MIT-SCREEN-SAVER extension is not loaded.")
+	}
+	info, err := screensaver.QueryInfo(X.Conn(),
+		xproto.Drawable(Root.Id)).Reply()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(info.MsSinceUserInput) * time.Millisecond, nil
 }
 
 func AddClient(c Client) {
@@ -207,6 +237,7 @@ func AddWorkspace(name string) error {
 		return fmt.Errorf("a workspace with name '%s' already exists.", name)
 	}
 	wrk := Heads.NewWorkspace(name)
+	wrk.GapsSet(Config.Gaps(name))
 	wrk.PromptSlctGroup = Prompts.Slct.AddGroup(wrk)
 	wrk.PromptSlctItem = Prompts.Slct.AddChoice(wrk)
 
@@ -258,14 +289,75 @@ func RootGeomChangeFun() xevent.ConfigureNotifyFun {
 		for i := len(Heads.Workspaces.Wrks); i < Heads.NumConnected(); i++ {
 			AddWorkspace(uniqueWorkspaceName())
 		}
+		oldNum := Heads.NumHeads()
 		Heads.Reload(Clients)
 		FocusFallback()
 		ewmhVisibleDesktops()
 		ewmhDesktopGeometry()
+		notifyHeads()
+		fireHeadHooks(oldNum)
 	}
 	return xevent.ConfigureNotifyFun(f)
 }
 
+// fireHeadHooks fires the head_added or head_removed hooks once for every
+// head that was added or removed, given the number of heads there were
+// before. Heads are numbered from left to right, so the heads that were added
+// or removed are taken to be the ones at the end.
+func fireHeadHooks(oldNum int) {
+	newNum := Heads.NumHeads()
+	for i := oldNum; i < newNum; i++ {
+		args := hook.Args{Head: fmt.Sprintf("%d", i)}
+		Heads.WithVisibleWorkspace(i, func(wrk *workspace.Workspace) {
+			args.Workspace = wrk.Name
+		})
+		hook.Fire(hook.HeadAdded, args)
+	}
+	for i := newNum; i < oldNum; i++ {
+		hook.Fire(hook.HeadRemoved, hook.Args{Head: fmt.Sprintf("%d", i)})
+	}
+}
+
+// notifyHeads tells subscribers about the geometry of every head.
+func notifyHeads() {
+	event.Notify(event.ChangedHeads{Heads: eventHeads()})
+}
+
+// eventHeads describes every head and the workspace visible on it.
+func eventHeads() []event.Head {
+	visibles := Heads.VisibleWorkspaces()
+	heads := make([]event.Head, 0, len(visibles))
+	for _, wrk := range visibles {
+		h := event.Head{Workspace: wrk.Name}
+		h.X, h.Y, h.Width, h.Height = xrect.Pieces(Heads.HeadGeom(wrk))
+		heads = append(heads, h)
+	}
+	return heads
+}
+
+// snapshot describes the current state of every workspace, head and client,
+// so that subscribers to events can get back in sync.
+func snapshot() event.Snapshot {
+	snap := event.Snapshot{
+		ActiveWorkspace: Workspace().Name,
+		Heads:           eventHeads(),
+	}
+	for _, wrk := range Heads.Workspaces.Wrks {
+		snap.Workspaces = append(snap.Workspaces, event.Workspace{
+			Name:   wrk.Name,
+			Layout: wrk.LayoutName(),
+			Head:   Heads.VisibleIndex(wrk),
+		})
+	}
+	for _, c := range Clients {
+		snap.Clients = append(snap.Clients, c.EventClient())
+	}
+	if c := focus.Current(); c != nil {
+		snap.ActiveClient = c.Id()
+	}
+	return snap
+}
+
 // uniqueWorkspaceName returns a workspace name that is guaranteed to be of
 // non-zero length and unique with respect to all other workspaces.
 func uniqueWorkspaceName() string {
@@ -7,6 +7,7 @@ import (
 	"github.com/BurntSushi/xgbutil/xgraphics"
 
 	"github.com/BurntSushi/wingo/frame"
+	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/logger"
 	"github.com/BurntSushi/wingo/misc"
 	"github.com/BurntSushi/wingo/prompt"
@@ -64,6 +65,27 @@ func (tf ThemeFull) FrameTheme() *frame.FullTheme {
 	}
 }
 
+// OutlineColor returns the color of the outline that previews where a window
+// being dragged will be snapped.
+func (tf ThemeFull) OutlineColor() render.Color {
+	return tf.aBorderColor
+}
+
+// TabTheme returns the theme used to draw tabs in the Tabbed and Stacked
+// layouts. Tabs look like the title bar of a Full frame.
+func (tf ThemeFull) TabTheme() *layout.TabTheme {
+	return &layout.TabTheme{
+		Font:        tf.font,
+		FontSize:    tf.fontSize,
+		AFontColor:  tf.aFontColor,
+		IFontColor:  tf.iFontColor,
+		TabSize:     tf.titleSize,
+		ATabColor:   tf.aTitleColor,
+		ITabColor:   tf.iTitleColor,
+		BorderColor: tf.iBorderColor,
+	}
+}
+
 type ThemeBorders struct {
 	borderSize                 int
 	aThinColor, iThinColor     render.Color
@@ -413,10 +435,11 @@ func loadPromptOption(theme *ThemeConfig, k wini.Key) {
 		// naughty!
 		if theme.Prompt.cycleIconTransparency < 0 ||
 			theme.Prompt.cycleIconTransparency > 100 {
-			logger.Warning.Printf("Illegal value '%s' provided for " +
-				"'cycle_icon_transparency'. Transparency " +
-				"values must be in the range [0, 100], " +
-				"inclusive. Using 100 by default.")
+			logger.Warning.Printf("Illegal value '%d' provided for "+
+				"'cycle_icon_transparency'. Transparency "+
+				"values must be in the range [0, 100], "+
+				"inclusive. Using 100 by default.",
+				theme.Prompt.cycleIconTransparency)
 			theme.Prompt.cycleIconTransparency = 100
 		}
 	case "select_active_font_color":
@@ -26,6 +26,8 @@ type Client interface {
 	IconifiedSet(iconified bool)
 	IsSticky() bool
 	IsActive() bool
+	PlacementPolicy() int
+	TransientFor() layout.Client
 
 	HasState(name string) bool
 	SaveState(name string)
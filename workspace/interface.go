@@ -27,7 +27,7 @@ type Sticky struct {
 }
 
 func (wrks *Workspaces) NewSticky() *Sticky {
-	return &Sticky{wrks.X, layout.NewFloating()}
+	return &Sticky{wrks.X, layout.NewFloating(wrks.X)}
 }
 
 func (wrk *Sticky) String() string {
@@ -8,6 +8,7 @@ import (
 	"github.com/BurntSushi/xgbutil/xrect"
 
 	"github.com/BurntSushi/wingo/event"
+	"github.com/BurntSushi/wingo/hook"
 	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/logger"
 	"github.com/BurntSushi/wingo/prompt"
@@ -27,6 +28,11 @@ type Workspace struct {
 	autoTilers   []layout.AutoTiler
 	curAutoTiler int
 
+	manualTilers   []layout.ManualTiler
+	curManualTiler int
+
+	gaps layout.Gaps
+
 	PromptSlctGroup *prompt.SelectGroupItem
 	PromptSlctItem  *prompt.SelectItem
 }
@@ -39,19 +45,30 @@ func (wrks *Workspaces) NewWorkspace(name string) *Workspace {
 		State:   Floating,
 		Clients: make([]Client, 0, 40),
 
-		curFloater:   0,
-		curAutoTiler: 0,
+		curFloater:     0,
+		curAutoTiler:   0,
+		curManualTiler: 0,
 	}
 
 	// Layouts must be listed in the order in which their corresponding
 	// constants are defined in the layout package.
 	wrk.floaters = []layout.Floater{
-		layout.NewFloating(),
+		layout.NewFloating(wrks.X),
 	}
 	wrk.autoTilers = []layout.AutoTiler{
 		layout.NewVertical(),
 		layout.NewHorizontal(),
 		layout.NewMaximized(),
+		layout.NewGrid(),
+		layout.NewSpiral(),
+		layout.NewDwindle(),
+		layout.NewCenteredMaster(),
+		layout.NewTabbed(wrk.X, wrks.tabTheme),
+		layout.NewStacked(wrk.X, wrks.tabTheme),
+		layout.NewPaper(),
+	}
+	wrk.manualTilers = []layout.ManualTiler{
+		layout.NewManual(),
 	}
 
 	if state, index := wrk.findLayout(wrks.defaultLayout); state != -1 {
@@ -62,6 +79,9 @@ func (wrks *Workspaces) NewWorkspace(name string) *Workspace {
 		case AutoTiling:
 			wrk.curAutoTiler = index
 			wrk.State = AutoTiling
+		case ManualTiling:
+			wrk.curManualTiler = index
+			wrk.State = ManualTiling
 		default:
 			panic(fmt.Sprintf("Unknown layout state '%d'.", state))
 		}
@@ -77,6 +97,9 @@ func (wrk *Workspace) Destroy() {
 	for _, lay := range wrk.autoTilers {
 		lay.Destroy()
 	}
+	for _, lay := range wrk.manualTilers {
+		lay.Destroy()
+	}
 	wrk.PromptSlctGroup.Destroy()
 	wrk.PromptSlctItem.Destroy()
 }
@@ -210,6 +233,33 @@ func (wrk *Workspace) setGeom(geom xrect.Rect) {
 	for _, lay := range wrk.autoTilers {
 		lay.SetGeom(geom)
 	}
+	for _, lay := range wrk.manualTilers {
+		lay.SetGeom(geom)
+	}
+}
+
+// Gaps returns the gaps used by the tiling layouts on this workspace.
+func (wrk *Workspace) Gaps() layout.Gaps {
+	return wrk.gaps
+}
+
+// GapsSet changes the gaps used by every tiling layout on this workspace, and
+// re-places the current layout. Negative gaps are treated as zero.
+func (wrk *Workspace) GapsSet(gaps layout.Gaps) {
+	if gaps.Inner < 0 {
+		gaps.Inner = 0
+	}
+	if gaps.Outer < 0 {
+		gaps.Outer = 0
+	}
+	wrk.gaps = gaps
+	for _, lay := range wrk.autoTilers {
+		lay.SetGaps(gaps)
+	}
+	for _, lay := range wrk.manualTilers {
+		lay.SetGaps(gaps)
+	}
+	wrk.Place()
 }
 
 func (wrk *Workspace) Show() {
@@ -258,6 +308,8 @@ func (wrk *Workspace) Place() {
 		// Nada nada limonada
 	case AutoTiling:
 		wrk.LayoutAutoTiler().Place()
+	case ManualTiling:
+		wrk.LayoutManualTiler().Place()
 	default:
 		panic("Layout mode not implemented.")
 	}
@@ -340,6 +392,10 @@ func (wrk *Workspace) LayoutAutoTiler() layout.AutoTiler {
 	return wrk.autoTilers[wrk.curAutoTiler]
 }
 
+func (wrk *Workspace) LayoutManualTiler() layout.ManualTiler {
+	return wrk.manualTilers[wrk.curManualTiler]
+}
+
 func (wrk *Workspace) addToFloaters(c Client) {
 	for _, floater := range wrk.floaters {
 		floater.Add(c)
@@ -359,20 +415,28 @@ func (wrk *Workspace) addToTilers(c Client) {
 	for _, autoTiler := range wrk.autoTilers {
 		autoTiler.Add(c)
 	}
+	for _, manualTiler := range wrk.manualTilers {
+		manualTiler.Add(c)
+	}
 }
 
 func (wrk *Workspace) removeFromTilers(c Client) {
 	for _, autoTiler := range wrk.autoTilers {
 		autoTiler.Remove(c)
 	}
+	for _, manualTiler := range wrk.manualTilers {
+		manualTiler.Remove(c)
+	}
 }
 
 func (wrk *Workspace) AutoCycle() {
 	if wrk.State == AutoTiling {
+		old := wrk.LayoutName()
+		wrk.LayoutAutoTiler().Unplace()
 		wrk.curAutoTiler = (wrk.curAutoTiler + 1) % len(wrk.autoTilers)
 		wrk.LayoutAutoTiler().Place()
 
-		event.Notify(event.ChangedLayout{wrk.Name})
+		wrk.layoutChanged(old)
 	}
 }
 
@@ -382,6 +446,8 @@ func (wrk *Workspace) Layout(c Client) layout.Layout {
 		return wrk.LayoutFloater()
 	case wrk.State == AutoTiling:
 		return wrk.LayoutAutoTiler()
+	case wrk.State == ManualTiling:
+		return wrk.LayoutManualTiler()
 	default:
 		panic(fmt.Sprintf("Unknown layout state '%d'.", wrk.State))
 	}
@@ -389,20 +455,39 @@ func (wrk *Workspace) Layout(c Client) layout.Layout {
 }
 
 func (wrk *Workspace) SetLayout(name string) {
+	oldState, old := wrk.State, wrk.LayoutName()
 	state, index := wrk.findLayout(name)
 	switch state {
 	case Floating:
 		wrk.curFloater = index
 		wrk.LayoutStateSet(Floating)
 	case AutoTiling:
+		// LayoutStateSet only undoes the current layout when the kind of
+		// layout changes, so a tiler being replaced by one of its own kind
+		// is undone here. (Otherwise, things like tab bars stick around.)
+		if oldState == AutoTiling && index != wrk.curAutoTiler {
+			wrk.LayoutAutoTiler().Unplace()
+		}
 		wrk.curAutoTiler = index
 		wrk.LayoutStateSet(AutoTiling)
+	case ManualTiling:
+		if oldState == ManualTiling && index != wrk.curManualTiler {
+			wrk.LayoutManualTiler().Unplace()
+		}
+		wrk.curManualTiler = index
+		wrk.LayoutStateSet(ManualTiling)
 	case -1: // couldn't find layout with name 'name'
 		logger.Warning.Printf("Unknown layout '%s'.", name)
 		return
 	default:
 		panic(fmt.Sprintf("Unknown layout state '%d'.", state))
 	}
+
+	// LayoutStateSet only says that the layout changed when the kind of
+	// layout changes.
+	if state == oldState && wrk.IsVisible() && old != wrk.LayoutName() {
+		wrk.layoutChanged(old)
+	}
 }
 
 func (wrk *Workspace) findLayout(name string) (state int, index int) {
@@ -425,6 +510,15 @@ func (wrk *Workspace) findLayout(name string) (state int, index int) {
 				break
 			}
 		}
+	}
+	if use == nil {
+		for i, lay := range wrk.manualTilers {
+			if name == strings.ToLower(lay.Name()) {
+				use = lay
+				index = i
+				break
+			}
+		}
 		if use == nil {
 			return -1, -1
 		}
@@ -434,6 +528,8 @@ func (wrk *Workspace) findLayout(name string) (state int, index int) {
 		return Floating, index
 	} else if _, ok := use.(layout.AutoTiler); ok {
 		return AutoTiling, index
+	} else if _, ok := use.(layout.ManualTiler); ok {
+		return ManualTiling, index
 	}
 	panic(fmt.Sprintf("Unknown layout type: %T", use))
 }
@@ -444,6 +540,8 @@ func (wrk *Workspace) LayoutName() string {
 		return wrk.LayoutFloater().Name()
 	case AutoTiling:
 		return wrk.LayoutAutoTiler().Name()
+	case ManualTiling:
+		return wrk.LayoutManualTiler().Name()
 	}
 	panic(fmt.Sprintf("Unknown workspace layout state: %d", wrk.State))
 }
@@ -454,20 +552,26 @@ func (wrk *Workspace) LayoutStateSet(state int) {
 	}
 
 	if state == wrk.State {
-		// If it's an AutoTiler, then just call Place again.
-		if wrk.State == AutoTiling {
+		// If it's a tiler, then just call Place again.
+		switch wrk.State {
+		case AutoTiling:
 			wrk.LayoutAutoTiler().Place()
+		case ManualTiling:
+			wrk.LayoutManualTiler().Place()
 		}
 		return
 	}
 
 	// First undo the current layout.
+	old := wrk.LayoutName()
 	switch wrk.State {
 	case Floating:
 		wrk.LayoutFloater().Save()
 		wrk.LayoutFloater().Unplace()
 	case AutoTiling:
 		wrk.LayoutAutoTiler().Unplace()
+	case ManualTiling:
+		wrk.LayoutManualTiler().Unplace()
 	default:
 		panic("Layout state not implemented.")
 	}
@@ -481,11 +585,26 @@ func (wrk *Workspace) LayoutStateSet(state int) {
 	case AutoTiling:
 		wrk.State = state
 		wrk.LayoutAutoTiler().Place()
+	case ManualTiling:
+		wrk.State = state
+		wrk.LayoutManualTiler().Place()
 	default:
 		panic("Layout state not implemented.")
 	}
 
-	event.Notify(event.ChangedLayout{wrk.Name})
+	wrk.layoutChanged(old)
+}
+
+// layoutChanged tells everyone that the layout of wrk has changed from the
+// layout named old.
+func (wrk *Workspace) layoutChanged(old string) {
+	event.Notify(event.ChangedLayout{
+		Workspace: wrk.Name, Old: old, New: wrk.LayoutName()})
+	hook.Fire(hook.LayoutChanged, hook.Args{
+		Workspace: wrk.Name,
+		OldLayout: old,
+		NewLayout: wrk.LayoutName(),
+	})
 }
 
 func (wrk *Workspace) SelectGroupText() string {
@@ -6,6 +6,8 @@ import (
 
 	"github.com/BurntSushi/xgbutil"
 	"github.com/BurntSushi/xgbutil/xrect"
+
+	"github.com/BurntSushi/wingo/layout"
 )
 
 const (
@@ -30,16 +32,18 @@ type Workspaces struct {
 	Wrks          []*Workspace
 	heads         Heads
 	defaultLayout string
+	tabTheme      *layout.TabTheme
 }
 
-func NewWorkspaces(
-	X *xgbutil.XUtil, heads Heads, defaultLayout string) *Workspaces {
+func NewWorkspaces(X *xgbutil.XUtil, heads Heads, defaultLayout string,
+	tabTheme *layout.TabTheme) *Workspaces {
 
 	return &Workspaces{
 		X:             X,
 		Wrks:          make([]*Workspace, 0, 1),
 		heads:         heads,
 		defaultLayout: defaultLayout,
+		tabTheme:      tabTheme,
 	}
 }
 
@@ -41,7 +41,8 @@ func writeConfigFiles() {
 	}
 
 	files := []string{
-		"hooks.wini", "key.wini", "mouse.wini", "options.wini", "theme.wini",
+		"hooks.wini", "key.wini", "mouse.wini", "options.wini", "rules.wini",
+		"theme.wini",
 	}
 	for _, f := range files {
 		dst := path.Join(configDir, f)
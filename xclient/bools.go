@@ -7,6 +7,7 @@ import (
 	"github.com/BurntSushi/xgbutil/motif"
 
 	"github.com/BurntSushi/wingo/frame"
+	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/logger"
 	"github.com/BurntSushi/wingo/wm"
 )
@@ -31,6 +32,17 @@ func (c *Client) Iconified() bool {
 	return c.iconified
 }
 
+func (c *Client) IsFullscreen() bool {
+	return c.fullscreen
+}
+
+// IsFloating returns true if the client is in a floating layout, either
+// because its workspace is floating or because it is forced to float.
+func (c *Client) IsFloating() bool {
+	_, ok := c.Layout().(layout.Floater)
+	return ok
+}
+
 func (c *Client) hasType(atom string) bool {
 	return strIndex(atom, c.winTypes) > -1
 }
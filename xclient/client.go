@@ -15,6 +15,7 @@ import (
 	"github.com/BurntSushi/wingo/frame"
 	"github.com/BurntSushi/wingo/hook"
 	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/rules"
 	"github.com/BurntSushi/wingo/stack"
 	"github.com/BurntSushi/wingo/wm"
 	"github.com/BurntSushi/wingo/workspace"
@@ -62,6 +63,7 @@ type Client struct {
 	nhints       *icccm.NormalHints
 	protocols    []string
 	class        *icccm.WmClass
+	role         string // WM_WINDOW_ROLE
 	transientFor *Client
 	time         xproto.Timestamp
 
@@ -73,12 +75,28 @@ type Client struct {
 	floating         bool
 	moving, resizing bool
 
+	// placement is one of layout.Place[...], or -1 when the placement policy
+	// in the configuration should be used.
+	placement int
+
 	dragGeom  xrect.Rect
+	dragStart xrect.Rect // Frame geometry when a move began.
+	lastGeom  xrect.Rect // Frame geometry last sent to subscribers.
 	hadStruts bool
 	shaped    bool
 
+	// snapped is one of the Snap[...] constants, and snapWidth is an index
+	// into snapWidths. dragSnap is where the client will be snapped when a
+	// drag ends, and dragSnapGeom is the head it will be snapped in.
+	snapped, snapWidth int
+	dragSnap           int
+	dragSnapGeom       xrect.Rect
+
 	attnQuit  chan struct{}
 	demanding bool
+
+	// The properties set by rules when the client was managed.
+	rules rules.Props
 }
 
 func (c *Client) Map() {
@@ -89,7 +107,7 @@ func (c *Client) Map() {
 	c.frame.Map()
 	icccm.WmStateSet(wm.X, c.Id(), &icccm.WmState{State: icccm.StateNormal})
 
-	event.Notify(event.MappedClient{c.Id()})
+	event.Notify(event.MappedClient{Client: c.EventClient()})
 }
 
 func (c *Client) Unmap() {
@@ -101,7 +119,7 @@ func (c *Client) Unmap() {
 	c.win.Unmap()
 	icccm.WmStateSet(wm.X, c.Id(), &icccm.WmState{State: icccm.StateIconic})
 
-	event.Notify(event.UnmappedClient{c.Id()})
+	event.Notify(event.UnmappedClient{Client: c.EventClient()})
 }
 
 func (c *Client) Close() {
@@ -140,6 +158,22 @@ func (c *Client) String() string {
 	return c.name
 }
 
+// EventClient returns the description of this client that is sent with
+// events about it.
+func (c *Client) EventClient() event.Client {
+	ec := event.Client{Id: c.Id(), Name: c.Name()}
+	if c.class != nil {
+		ec.Class, ec.Instance = c.class.Class, c.class.Instance
+	}
+	if c.workspace != nil {
+		ec.Workspace = c.workspace.String()
+	}
+	if c.frame != nil {
+		ec.X, ec.Y, ec.Width, ec.Height = xrect.Pieces(c.frame.Geom())
+	}
+	return ec
+}
+
 func (c *Client) Id() xproto.Window {
 	return c.win.Id
 }
@@ -175,6 +209,16 @@ func (c *Client) Class() *icccm.WmClass {
 	return c.class
 }
 
+func (c *Client) Role() string {
+	return c.role
+}
+
+// WindowTypes returns the EWMH window types of the client, like
+// _NET_WM_WINDOW_TYPE_DIALOG.
+func (c *Client) WindowTypes() []string {
+	return c.winTypes
+}
+
 func (c *Client) Raise() {
 	stack.Raise(c)
 }
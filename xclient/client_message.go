@@ -31,6 +31,7 @@ func (c *Client) handleClientMessage(name string, data []uint32) {
 		xflags := int((data[0] >> 8) & 0xf)
 		x, y, w, h := frame.ClientToFrame(c.frame, gravity,
 			int(data[1]), int(data[2]), int(data[3]), int(data[4]))
+		c.unsnap()
 		c.LayoutMROpt(xflags, x, y, w, h)
 	case "_NET_RESTACK_WINDOW":
 		// We basically treat this as a request to stack the window.
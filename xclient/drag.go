@@ -28,19 +28,27 @@ func (c *Client) DragMoveBegin(rx, ry, ex, ey int) bool {
 	f.Parent().Geometry()
 
 	c.dragGeom = xrect.New(xrect.Pieces(f.Geom()))
+	if c.snapped != SnapNone {
+		c.dragUnsnap(rx)
+	}
+	c.dragStart = xrect.New(xrect.Pieces(c.dragGeom))
 	return true
 }
 
 func (c *Client) DragMoveStep(rx, ry, ex, ey int) {
 	f := c.frame
 	moving := f.MovingState()
-	newx := c.dragGeom.X() + rx - moving.RootX
-	newy := c.dragGeom.Y() + ry - moving.RootY
-	moving.RootX, moving.RootY = rx, ry
+
+	// The root position stays where the drag began, so that the pointer
+	// always says where the window would be if it hadn't snapped.
+	newx := c.dragStart.X() + rx - moving.RootX
+	newy := c.dragStart.Y() + ry - moving.RootY
+	newx, newy = c.snapMove(newx, newy)
 
 	c.dragGeom.XSet(newx)
 	c.dragGeom.YSet(newy)
 	c.LayoutMove(newx, newy)
+	c.dragSnapStep(rx, ry)
 }
 
 func (c *Client) DragMoveEnd(rx, ry, ex, ey int) {
@@ -51,6 +59,9 @@ func (c *Client) DragMoveEnd(rx, ry, ex, ey int) {
 	moving.Moving = false
 	moving.RootX, moving.RootY = 0, 0
 	c.dragGeom = nil
+	c.dragStart = nil
+	c.dragSnapEnd()
+	c.geomChanged()
 }
 
 func (c *Client) DragResizeBegin(direction uint32,
@@ -59,6 +70,7 @@ func (c *Client) DragResizeBegin(direction uint32,
 	if c.IsMaximized() {
 		return false, 0
 	}
+	c.unsnap()
 	f := c.frame
 
 	// call for side-effect; makes sure parent window has a valid geometry
@@ -192,7 +204,17 @@ func (c *Client) DragResizeStep(rx, ry, ex, ey int) {
 		} else {
 			neww = resizing.Width + diffx
 		}
+	}
+	if resizing.Hs {
+		if resizing.Ys {
+			newh = resizing.Height - diffy
+		} else {
+			newh = resizing.Height + diffy
+		}
+	}
+	newx, newy, neww, newh = c.snapResize(newx, newy, neww, newh)
 
+	if resizing.Ws {
 		leftRight := f.Left() + f.Right()
 		validw = c.ValidateWidth(neww-leftRight) + leftRight
 
@@ -203,12 +225,6 @@ func (c *Client) DragResizeStep(rx, ry, ex, ey int) {
 		}
 	}
 	if resizing.Hs {
-		if resizing.Ys {
-			newh = resizing.Height - diffy
-		} else {
-			newh = resizing.Height + diffy
-		}
-
 		topBot := f.Top() + f.Bottom()
 		validh = c.ValidateHeight(newh-topBot) + topBot
 
@@ -245,4 +261,5 @@ func (c *Client) DragResizeEnd(rx, ry, ex, ey int) {
 	resizing.Xs, resizing.Ys = false, false
 	resizing.Ws, resizing.Hs = false, false
 	c.dragGeom = nil
+	c.geomChanged()
 }
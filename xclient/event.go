@@ -123,6 +123,7 @@ func (c *Client) cbConfigureRequest() xevent.ConfigureRequestFun {
 			^int(xproto.ConfigWindowSibling)
 		x, y, w, h := frame.ClientToFrame(c.frame, -1,
 			int(ev.X), int(ev.Y), int(ev.Width), int(ev.Height))
+		c.unsnap()
 		c.LayoutMROpt(flags, x, y, w, h)
 	}
 	return xevent.ConfigureRequestFun(f)
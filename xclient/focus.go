@@ -23,9 +23,10 @@ func (c *Client) Focused() {
 	focus.SetFocus(c)
 	ewmh.ActiveWindowSet(wm.X, c.Id())
 	c.addState("_NET_WM_STATE_FOCUSED")
+	c.redecorate()
 
-	event.Notify(event.FocusedClient{c.Id()})
-	event.Notify(event.ChangedActiveClient{c.Id()})
+	event.Notify(event.FocusedClient{Client: c.EventClient()})
+	event.Notify(event.ChangedActiveClient{Client: c.EventClient()})
 	c.FireHook(hook.Focused)
 }
 
@@ -36,10 +37,11 @@ func (c *Client) Unfocused() {
 	c.state = frame.Inactive
 	ewmh.ActiveWindowSet(wm.X, 0)
 	c.removeState("_NET_WM_STATE_FOCUSED")
+	c.redecorate()
 
 	if wasFocused {
-		event.Notify(event.UnfocusedClient{c.Id()})
-		event.Notify(event.ChangedActiveClient{0})
+		event.Notify(event.UnfocusedClient{Client: c.EventClient()})
+		event.Notify(event.ChangedActiveClient{Client: event.Client{}})
 		c.FireHook(hook.Unfocused)
 	}
 }
@@ -49,16 +49,33 @@ func (c *Client) Frame() frame.Frame {
 	return c.frame
 }
 
+// FrameName returns the name of the current frame in use by the client:
+// "full", "borders", "slim" or "nada".
+func (c *Client) FrameName() string {
+	switch c.frame.(type) {
+	case *frame.Full:
+		return "full"
+	case *frame.Borders:
+		return "borders"
+	case *frame.Slim:
+		return "slim"
+	case *frame.Nada:
+		return "nada"
+	}
+	return ""
+}
+
 // Geom returns the geometry of the client window (not the frame window).
 func (c *Client) ClientGeom() xrect.Rect {
 	return c.win.Geom
 }
 
-// EnsureUnmax makes sure the client is not in a maximized state.
+// EnsureUnmax makes sure the client is not in a maximized or snapped state.
 // It's useful when a particular operation that doesn't work in maximized mode
 // overrides a client's maximized state. (Like issuing a tiling request.)
 func (c *Client) EnsureUnmax() {
 	c.unmaximize()
+	c.unsnap()
 }
 
 func (c *Client) HeadGeom() xrect.Rect {
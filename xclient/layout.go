@@ -5,6 +5,7 @@ import (
 
 	"github.com/BurntSushi/xgbutil/xrect"
 
+	"github.com/BurntSushi/wingo/event"
 	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/wm"
 	"github.com/BurntSushi/wingo/workspace"
@@ -35,6 +36,41 @@ func (c *Client) Layout() layout.Layout {
 	return c.workspace.Layout(c)
 }
 
+// PlacementPolicy returns the policy used to place this client when it is
+// first managed in a floating layout.
+func (c *Client) PlacementPolicy() int {
+	if c.placement > -1 {
+		return c.placement
+	}
+	return wm.Config.FloatingPlacement
+}
+
+// PlacementPolicySet overrides the placement policy in the configuration
+// for this client only.
+func (c *Client) PlacementPolicySet(policy int) {
+	c.placement = policy
+}
+
+// TransientFor returns the client that this client is transient for, or nil
+// if it isn't transient.
+func (c *Client) TransientFor() layout.Client {
+	if c.transientFor == nil {
+		return nil
+	}
+	return c.transientFor
+}
+
+// redecorate tells the client's layout that its name or focus state has
+// changed, if the layout cares.
+func (c *Client) redecorate() {
+	if c.workspace == nil {
+		return
+	}
+	if dec, ok := c.Layout().(layout.Decorator); ok {
+		dec.Redecorate(c)
+	}
+}
+
 func (c *Client) LayoutMROpt(flags, x, y, width, height int) {
 	c.resizing = true
 	c.Layout().MROpt(c, flags, x, y, width, height)
@@ -82,14 +118,17 @@ func (c *Client) MROpt(validate bool, flags, x, y, w, h int) {
 
 		c.sendConfigureNotify()
 	}
+	c.geomChanged()
 }
 
 func (c *Client) MoveResize(x, y, width, height int) {
 	c.frame.MoveResize(false, x, y, width, height)
+	c.geomChanged()
 }
 
 func (c *Client) MoveResizeValid(x, y, width, height int) {
 	c.frame.MoveResize(true, x, y, width, height)
+	c.geomChanged()
 }
 
 func (c *Client) Move(x, y int) {
@@ -98,8 +137,31 @@ func (c *Client) Move(x, y int) {
 	// As per ICCCM 4.1.5, a window that has been moved but not resized must
 	// receive a synthetic ConfigureNotify event.
 	c.sendConfigureNotify()
+	c.geomChanged()
 }
 
 func (c *Client) Resize(validate bool, width, height int) {
 	c.frame.Resize(validate, width, height)
+	c.geomChanged()
+}
+
+// geomChanged tells subscribers that the geometry of the client changed.
+// Nothing is sent in the middle of a drag, since that would flood subscribers
+// with an event for every motion of the pointer. Instead, the drag sends one
+// when it ends. Nothing is sent either if the geometry is the same as the one
+// sent last, which happens a lot when a layout places every client again.
+func (c *Client) geomChanged() {
+	if c.frame.MovingState().Moving || c.frame.ResizingState().Resizing {
+		return
+	}
+	geom := c.frame.Geom()
+	if c.lastGeom != nil &&
+		c.lastGeom.X() == geom.X() && c.lastGeom.Y() == geom.Y() &&
+		c.lastGeom.Width() == geom.Width() &&
+		c.lastGeom.Height() == geom.Height() {
+
+		return
+	}
+	c.lastGeom = xrect.New(xrect.Pieces(geom))
+	event.Notify(event.ChangedClientGeometry{Client: c.EventClient()})
 }
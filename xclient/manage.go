@@ -17,7 +17,9 @@ import (
 	"github.com/BurntSushi/wingo/frame"
 	"github.com/BurntSushi/wingo/heads"
 	"github.com/BurntSushi/wingo/hook"
+	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/rules"
 	"github.com/BurntSushi/wingo/stack"
 	"github.com/BurntSushi/wingo/wm"
 	"github.com/BurntSushi/wingo/workspace"
@@ -64,6 +66,7 @@ func New(id xproto.Window) *Client {
 		skipTaskbar: false,
 		skipPager:   false,
 		demanding:   false,
+		placement:   -1,
 		attnQuit:    make(chan struct{}, 0),
 	}
 
@@ -74,13 +77,22 @@ func New(id xproto.Window) *Client {
 	// If someone really wants it, we can add a new "startup_managed" hook
 	// or something.
 	if !wm.Startup {
-		event.Notify(event.ManagedClient{c.Id()})
+		event.Notify(event.ManagedClient{Client: c.EventClient()})
 		c.FireHook(hook.Managed)
 	}
-	if !c.iconified {
+	// A client that goes to a hidden workspace is mapped when the workspace
+	// becomes visible.
+	if !c.iconified && c.workspace.IsVisible() {
 		c.Map()
 		if !wm.Startup && c.PrimaryType() == TypeNormal {
-			if !wm.Config.Ffm || wm.Config.FfmStartupFocus {
+			focus := !wm.Config.Ffm || wm.Config.FfmStartupFocus
+			switch c.rules.Focus {
+			case rules.On:
+				focus = true
+			case rules.Off:
+				focus = false
+			}
+			if focus {
 				c.Focus()
 			}
 		}
@@ -104,6 +116,7 @@ func (c *Client) manage() {
 	c.fetchXProperties()
 	c.setPrimaryType()
 	c.setInitialLayer()
+	c.findRules()
 
 	// Determine whether the client should start iconified or not.
 	c.iconified = c.nhints.Flags&icccm.HintState > 0 &&
@@ -112,8 +125,12 @@ func (c *Client) manage() {
 	// newClientFrames sets c.frame.
 	c.frames = c.newClientFrames()
 	c.states = c.newClientStates()
+	c.applyRuleFrame()
 
-	presumedWorkspace := c.findPresumedWorkspace()
+	presumedWorkspace := c.ruleWorkspace(c.findPresumedWorkspace())
+	if c.rules.Floating == rules.On {
+		c.floating = true
+	}
 
 	c.moveToProperHead(presumedWorkspace)
 	c.maybeInitPlace(presumedWorkspace)
@@ -130,6 +147,7 @@ func (c *Client) manage() {
 	}
 
 	c.updateInitStates()
+	c.applyRuleStates()
 	ewmh.WmAllowedActionsSet(wm.X, c.Id(), allowedActions)
 
 	err := xproto.ChangeSaveSetChecked(
@@ -186,6 +204,12 @@ func (c *Client) maybeInitPlace(presumedWorkspace workspace.Workspacer) {
 		}
 	}()
 
+	// A geometry set by a rule is used instead of any placement.
+	if c.rules.Geometry != nil {
+		c.placeByRule(presumedWorkspace)
+		return
+	}
+
 	// Any client that isn't normal doesn't get placed.
 	// Let it do what it do, baby.
 	if c.PrimaryType() != TypeNormal {
@@ -197,8 +221,8 @@ func (c *Client) maybeInitPlace(presumedWorkspace workspace.Workspacer) {
 		return
 	}
 
-	// Transients never get placed.
-	if c.transientFor != nil {
+	// Transients only get placed when they should go on top of their parent.
+	if c.transientFor != nil && c.PlacementPolicy() != layout.PlaceTransient {
 		return
 	}
 
@@ -270,6 +294,9 @@ func (c *Client) fetchXProperties() {
 		}
 	}
 
+	c.role, _ = xprop.PropValStr(
+		xprop.GetProperty(wm.X, c.Id(), "WM_WINDOW_ROLE"))
+
 	trans, _ := icccm.WmTransientForGet(wm.X, c.Id())
 	if trans == 0 {
 		for _, c2_ := range wm.Clients {
@@ -7,6 +7,7 @@ import (
 	"github.com/BurntSushi/xgbutil/icccm"
 
 	"github.com/BurntSushi/wingo/event"
+	"github.com/BurntSushi/wingo/hook"
 	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/wm"
 )
@@ -82,9 +83,12 @@ func (c *Client) refreshName() {
 			c.name = newName
 			c.frames.full.UpdateTitle()
 			c.prompts.updateName()
+			c.redecorate()
 			ewmh.WmVisibleNameSet(wm.X, c.Id(), c.name)
 
-			event.Notify(event.ChangedClientName{c.Id()})
+			event.Notify(
+				event.ChangedClientName{Client: c.EventClient()})
+			c.FireHook(hook.TitleChanged)
 		}
 	}()
 
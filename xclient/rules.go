@@ -0,0 +1,107 @@
+package xclient
+
+import (
+	"github.com/BurntSushi/xgbutil/ewmh"
+	"github.com/BurntSushi/xgbutil/xrect"
+
+	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/rules"
+	"github.com/BurntSushi/wingo/wm"
+	"github.com/BurntSushi/wingo/workspace"
+)
+
+// findRules finds the properties set by the rules that match this client.
+// It must be called after the client's X properties have been fetched.
+func (c *Client) findRules() {
+	c.rules = rules.Find(rules.Window{
+		Class:     c.class.Class,
+		Instance:  c.class.Instance,
+		Role:      c.role,
+		Name:      c.Name(),
+		Types:     c.winTypes,
+		Transient: c.transientFor != nil,
+	})
+}
+
+// ruleWorkspace returns the workspace that a new client should go to given
+// its rules, or presumed if no rule says otherwise.
+func (c *Client) ruleWorkspace(
+	presumed workspace.Workspacer) workspace.Workspacer {
+
+	switch c.rules.Sticky {
+	case rules.On:
+		return wm.StickyWrk
+	case rules.Off:
+		if _, ok := presumed.(*workspace.Sticky); ok {
+			presumed = wm.Workspace()
+		}
+	}
+	if len(c.rules.Workspace) > 0 {
+		if wrk := wm.Heads.Workspaces.Find(c.rules.Workspace); wrk != nil {
+			return wrk
+		}
+		logger.Warning.Printf("Could not find workspace '%s' for '%s'.",
+			c.rules.Workspace, c)
+	}
+	if c.rules.Head > -1 {
+		wm.Heads.WithVisibleWorkspace(c.rules.Head,
+			func(wrk *workspace.Workspace) {
+				presumed = wrk
+			})
+	}
+	return presumed
+}
+
+// placeByRule moves the client to the geometry set by its rules, relative to
+// the workspace it's going to. If that workspace isn't visible, the geometry
+// is saved so it can be used when the workspace becomes visible.
+func (c *Client) placeByRule(wrk workspace.Workspacer) {
+	g := c.rules.Geometry
+	if wrk.IsVisible() {
+		base := wrk.Geom()
+		c.MoveResize(base.X()+g.X(), base.Y()+g.Y(), g.Width(), g.Height())
+		return
+	}
+
+	active := wm.Workspace()
+	base := active.Geom()
+	c.MoveResize(base.X()+g.X(), base.Y()+g.Y(), g.Width(), g.Height())
+	c.states["workspace-switch"] = clientState{
+		geom:      xrect.New(xrect.Pieces(c.frame.Geom())),
+		headGeom:  xrect.New(xrect.Pieces(active.HeadGeom())),
+		frame:     c.frame,
+		maximized: false,
+	}
+}
+
+// applyRuleFrame switches to the frame set by the client's rules.
+func (c *Client) applyRuleFrame() {
+	switch c.rules.Frame {
+	case "full":
+		c.FrameFull()
+	case "borders":
+		c.FrameBorders()
+	case "slim":
+		c.FrameSlim()
+	case "nada":
+		c.FrameNada()
+	}
+}
+
+// applyRuleStates sets the layer and opacity from the client's rules. It
+// should be called after the initial EWMH states are applied, so that rules
+// take precedence.
+func (c *Client) applyRuleStates() {
+	switch c.rules.Layer {
+	case "above":
+		c.stackAbove()
+	case "below":
+		c.stackBelow()
+	case "default":
+		c.unstackAbove()
+		c.unstackBelow()
+	}
+	if c.rules.Opacity > -1 {
+		ewmh.WmWindowOpacitySet(wm.X, c.frame.Parent().Id, c.rules.Opacity)
+	}
+}
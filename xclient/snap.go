@@ -0,0 +1,196 @@
+package xclient
+
+import (
+	"github.com/BurntSushi/xgbutil/xrect"
+
+	"github.com/BurntSushi/wingo/layout"
+	"github.com/BurntSushi/wingo/wm"
+)
+
+// edge is a line that the edges of a window being dragged are attracted to.
+// For a vertical line, pos is its x coordinate and lo and hi are the y
+// coordinates of its ends. For a horizontal line, it's the other way around.
+type edge struct {
+	pos, lo, hi int
+}
+
+// snapEdges holds all of the lines that a window being dragged snaps to,
+// and the boundaries between adjacent heads, which resist being crossed.
+type snapEdges struct {
+	xs, ys           []edge
+	boundXs, boundYs []edge
+}
+
+// snapEdges finds the edges of every head (with and without struts) and of
+// every other visible client.
+func (c *Client) snapEdges() *snapEdges {
+	se := &snapEdges{}
+	heads := wm.Heads.Geoms()
+	for _, geom := range heads {
+		se.addRect(geom)
+	}
+	for _, geom := range wm.Heads.Workareas() {
+		se.addRect(geom)
+	}
+	for _, cl := range wm.Clients {
+		if c2 := cl.(*Client); c2 != c && c2.IsMapped() {
+			se.addRect(c2.frame.Geom())
+		}
+	}
+
+	for _, h1 := range heads {
+		for _, h2 := range heads {
+			if h1.X()+h1.Width() == h2.X() {
+				lo := max(h1.Y(), h2.Y())
+				hi := min(h1.Y()+h1.Height(), h2.Y()+h2.Height())
+				if lo < hi {
+					se.boundXs = append(se.boundXs, edge{h2.X(), lo, hi})
+				}
+			}
+			if h1.Y()+h1.Height() == h2.Y() {
+				lo := max(h1.X(), h2.X())
+				hi := min(h1.X()+h1.Width(), h2.X()+h2.Width())
+				if lo < hi {
+					se.boundYs = append(se.boundYs, edge{h2.Y(), lo, hi})
+				}
+			}
+		}
+	}
+	return se
+}
+
+func (se *snapEdges) addRect(r xrect.Rect) {
+	x1, y1 := r.X(), r.Y()
+	x2, y2 := x1+r.Width(), y1+r.Height()
+	se.xs = append(se.xs, edge{x1, y1, y2}, edge{x2, y1, y2})
+	se.ys = append(se.ys, edge{y1, x1, x2}, edge{y2, x1, x2})
+}
+
+// canSnap returns true if the client is in a layout where it may be moved
+// around freely.
+func (c *Client) canSnap() bool {
+	_, ok := c.Layout().(layout.Floater)
+	return ok
+}
+
+// snapMove takes the position of a window being moved, as dictated by the
+// pointer, and returns the position where the window should actually go.
+// An edge of the window that has just been dragged across the boundary
+// between two heads stays on the boundary until it has been dragged past it
+// by more than the edge resistance. Otherwise, the edges of the window snap
+// to any edges within the snap distance.
+func (c *Client) snapMove(x, y int) (int, int) {
+	if !c.canSnap() {
+		return x, y
+	}
+	se := c.snapEdges()
+	w, h := c.dragGeom.Width(), c.dragGeom.Height()
+	start := c.dragStart
+	resistance, dist := wm.Config.EdgeResistance, wm.Config.SnapDistance
+
+	nx, xheld := resistSpan(x, w, start.X(), y, y+h, se.boundXs, resistance)
+	ny, yheld := resistSpan(y, h, start.Y(), x, x+w, se.boundYs, resistance)
+	if !xheld {
+		nx = snapSpan(x, w, y, y+h, se.xs, dist)
+	}
+	if !yheld {
+		ny = snapSpan(y, h, x, x+w, se.ys, dist)
+	}
+	return nx, ny
+}
+
+// snapResize is like snapMove, except only the edges of the window that are
+// being dragged snap, and there is no edge resistance.
+func (c *Client) snapResize(x, y, w, h int) (int, int, int, int) {
+	if !c.canSnap() {
+		return x, y, w, h
+	}
+	se := c.snapEdges()
+	resizing := c.frame.ResizingState()
+	dist := wm.Config.SnapDistance
+
+	if resizing.Xs {
+		if d, ok := snap(x, y, y+h, se.xs, dist); ok {
+			x, w = x+d, w-d
+		}
+	} else if resizing.Ws {
+		if d, ok := snap(x+w, y, y+h, se.xs, dist); ok {
+			w += d
+		}
+	}
+	if resizing.Ys {
+		if d, ok := snap(y, x, x+w, se.ys, dist); ok {
+			y, h = y+d, h-d
+		}
+	} else if resizing.Hs {
+		if d, ok := snap(y+h, x, x+w, se.ys, dist); ok {
+			h += d
+		}
+	}
+	return x, y, w, h
+}
+
+// snap returns the distance that p must move to line up with the closest
+// edge that is within dist of it. Only edges that run alongside [lo, hi) are
+// considered. If there is no such edge, false is returned.
+func snap(p, lo, hi int, edges []edge, dist int) (int, bool) {
+	best, found := 0, false
+	if dist <= 0 {
+		return best, found
+	}
+	for _, e := range edges {
+		if e.hi < lo-dist || e.lo > hi+dist {
+			continue
+		}
+		d := e.pos - p
+		if abs(d) <= dist && (!found || abs(d) < abs(best)) {
+			best, found = d, true
+		}
+	}
+	return best, found
+}
+
+// snapSpan is like snap, except it returns the new start of the span
+// [p, p+size) after lining up whichever of its ends is closest to an edge.
+func snapSpan(p, size, lo, hi int, edges []edge, dist int) int {
+	d1, ok1 := snap(p, lo, hi, edges, dist)
+	d2, ok2 := snap(p+size, lo, hi, edges, dist)
+	switch {
+	case ok1 && (!ok2 || abs(d1) <= abs(d2)):
+		return p + d1
+	case ok2:
+		return p + d2
+	}
+	return p
+}
+
+// resistSpan returns the new start of the span [p, p+size) if either of its
+// ends is being held at a boundary. start is where the span started when
+// the drag began.
+func resistSpan(p, size, start, lo, hi int,
+	bounds []edge, resistance int) (int, bool) {
+
+	if b, ok := resist(p, start, lo, hi, bounds, resistance); ok {
+		return b, true
+	}
+	if b, ok := resist(p+size, start+size, lo, hi, bounds, resistance); ok {
+		return b - size, true
+	}
+	return p, false
+}
+
+// resist returns the position of a boundary if p has crossed it, coming
+// from the side that start is on, by no more than resistance.
+func resist(p, start, lo, hi int, bounds []edge, resistance int) (int, bool) {
+	for _, b := range bounds {
+		if b.hi <= lo || b.lo >= hi {
+			continue
+		}
+		if (start < b.pos && p > b.pos && p-b.pos <= resistance) ||
+			(start > b.pos && p < b.pos && b.pos-p <= resistance) {
+
+			return b.pos, true
+		}
+	}
+	return p, false
+}
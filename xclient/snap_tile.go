@@ -0,0 +1,263 @@
+package xclient
+
+import (
+	"github.com/BurntSushi/xgb/xproto"
+
+	"github.com/BurntSushi/xgbutil/xrect"
+	"github.com/BurntSushi/xgbutil/xwindow"
+
+	"github.com/BurntSushi/wingo/logger"
+	"github.com/BurntSushi/wingo/wm"
+)
+
+// Places in a head that a floating client can be snapped to.
+const (
+	SnapNone = iota
+	SnapLeft
+	SnapRight
+	SnapTop
+	SnapTopLeft
+	SnapTopRight
+	SnapBottomLeft
+	SnapBottomRight
+)
+
+// snapWidths are the widths, as a fraction of the head, that a client cycles
+// through when it is snapped to the same place more than once.
+var snapWidths = []float64{1.0 / 2.0, 1.0 / 3.0, 2.0 / 3.0}
+
+const (
+	// snapEdgeSize is how close, in pixels, the pointer must be to the edge
+	// of a head to snap a window being dragged.
+	snapEdgeSize = 2
+
+	// snapCornerRatio decides how much of each edge of a head counts as a
+	// corner. A corner is 1/snapCornerRatio of the edge long.
+	snapCornerRatio = 8
+
+	// snapOutlineSize is the thickness of the outline that previews where a
+	// window being dragged will be snapped.
+	snapOutlineSize = 4
+)
+
+// snapOutline is shared by all clients, since only one client can be dragged
+// at a time. It is created the first time it's needed.
+var snapOutline *outline
+
+// Snap makes the client fill the part of its head given by place, where
+// place is one of the Snap[...] constants. Snapping a client to the same
+// place more than once cycles through the widths in snapWidths. Snapping to
+// the top maximizes the client.
+func (c *Client) Snap(place int) {
+	if !c.canMaxUnmax() {
+		return
+	}
+	c.snapTo(place, c.Workspace().Geom())
+}
+
+func (c *Client) snapTo(place int, geom xrect.Rect) {
+	if place == SnapNone {
+		return
+	}
+	if place == SnapTop {
+		c.Maximize()
+		return
+	}
+
+	if place == c.snapped {
+		c.snapWidth = (c.snapWidth + 1) % len(snapWidths)
+	} else {
+		c.snapWidth = 0
+	}
+
+	// Remember the geometry from before the client was snapped, so that it
+	// can be restored when the client is dragged away. A maximized client
+	// already remembers that.
+	if c.snapped == SnapNone {
+		if c.IsMaximized() {
+			c.CopyState("before-maximize", "before-snap")
+		} else {
+			c.SaveState("before-snap")
+		}
+	}
+	c.unmaximize()
+	c.snapped = place
+
+	g := snapGeom(geom, place, snapWidths[c.snapWidth])
+	c.LayoutMoveResize(g.X(), g.Y(), g.Width(), g.Height())
+}
+
+// unsnap forgets that the client is snapped to anything. It should be called
+// whenever the geometry of the client is changed by something other than
+// snapping.
+func (c *Client) unsnap() {
+	c.snapped = SnapNone
+	c.snapWidth = 0
+	delete(c.states, "before-snap")
+}
+
+// dragUnsnap gives a snapped client that has just started being dragged the
+// size it had before it was snapped. The client is moved so that the pointer
+// is at the same relative spot across the width of the client.
+func (c *Client) dragUnsnap(rx int) {
+	s, ok := c.states["before-snap"]
+	c.unsnap()
+	if !ok {
+		return
+	}
+
+	g := c.dragGeom
+	w, h := s.geom.Width(), s.geom.Height()
+	x := rx - (rx-g.X())*w/g.Width()
+	c.dragGeom = xrect.New(x, g.Y(), w, h)
+	c.LayoutMoveResize(x, g.Y(), w, h)
+}
+
+// dragSnapStep shows where a client being dragged will be snapped if it is
+// dropped with the pointer at (rx, ry), if anywhere.
+func (c *Client) dragSnapStep(rx, ry int) {
+	if !wm.Config.EdgeTiling || !c.canSnap() {
+		return
+	}
+
+	place, geom := dragSnapPlace(rx, ry)
+	c.dragSnap, c.dragSnapGeom = place, geom
+	if place == SnapNone {
+		if snapOutline != nil {
+			snapOutline.hide()
+		}
+		return
+	}
+	if snapOutline == nil {
+		if snapOutline = newOutline(); snapOutline == nil {
+			return
+		}
+	}
+	if place == SnapTop {
+		snapOutline.show(geom)
+	} else {
+		snapOutline.show(snapGeom(geom, place, snapWidths[0]))
+	}
+}
+
+// dragSnapEnd snaps a client that has just been dropped, if it was dropped
+// at the edge of a head.
+func (c *Client) dragSnapEnd() {
+	if snapOutline != nil {
+		snapOutline.hide()
+	}
+	place, geom := c.dragSnap, c.dragSnapGeom
+	c.dragSnap, c.dragSnapGeom = SnapNone, nil
+	if place != SnapNone {
+		c.snapTo(place, geom)
+	}
+}
+
+// dragSnapPlace returns the place that a client being dragged should be
+// snapped to if it is dropped with the pointer at (rx, ry), and the geometry
+// (with struts applied) of the head that the pointer is on.
+func dragSnapPlace(rx, ry int) (int, xrect.Rect) {
+	workareas := wm.Heads.Workareas()
+	for i, g := range wm.Heads.Geoms() {
+		x, y, w, h := g.X(), g.Y(), g.Width(), g.Height()
+		if rx < x || rx >= x+w || ry < y || ry >= y+h {
+			continue
+		}
+
+		left, right := rx < x+snapEdgeSize, rx >= x+w-snapEdgeSize
+		top, bottom := ry < y+snapEdgeSize, ry >= y+h-snapEdgeSize
+		nearLeft := rx < x+w/snapCornerRatio
+		nearRight := rx >= x+w-w/snapCornerRatio
+		nearTop := ry < y+h/snapCornerRatio
+		nearBottom := ry >= y+h-h/snapCornerRatio
+
+		place := SnapNone
+		switch {
+		case (left && nearTop) || (top && nearLeft):
+			place = SnapTopLeft
+		case (right && nearTop) || (top && nearRight):
+			place = SnapTopRight
+		case (left && nearBottom) || (bottom && nearLeft):
+			place = SnapBottomLeft
+		case (right && nearBottom) || (bottom && nearRight):
+			place = SnapBottomRight
+		case left:
+			place = SnapLeft
+		case right:
+			place = SnapRight
+		case top:
+			place = SnapTop
+		}
+		return place, workareas[i]
+	}
+	return SnapNone, nil
+}
+
+// snapGeom returns the part of geom given by place, where width is the
+// fraction of geom that the result should span horizontally.
+func snapGeom(geom xrect.Rect, place int, width float64) xrect.Rect {
+	x, y := geom.X(), geom.Y()
+	w, h := int(float64(geom.Width())*width), geom.Height()
+
+	switch place {
+	case SnapTop:
+		w = geom.Width()
+	case SnapRight, SnapTopRight, SnapBottomRight:
+		x += geom.Width() - w
+	}
+	switch place {
+	case SnapTopLeft, SnapTopRight:
+		h /= 2
+	case SnapBottomLeft, SnapBottomRight:
+		h /= 2
+		y += geom.Height() - h
+	}
+	return xrect.New(x, y, w, h)
+}
+
+// outline is a hollow rectangle drawn on top of everything else, made out of
+// a window for each side.
+type outline struct {
+	sides [4]*xwindow.Window
+}
+
+func newOutline() *outline {
+	o := &outline{}
+	for i := range o.sides {
+		win, err := xwindow.Create(wm.X, wm.X.RootWin())
+		if err != nil {
+			logger.Warning.Printf("Could not create outline window: %s", err)
+			for _, side := range o.sides[:i] {
+				side.Destroy()
+			}
+			return nil
+		}
+		win.Change(xproto.CwOverrideRedirect, 1)
+		win.Change(xproto.CwBackPixel, wm.Theme.Full.OutlineColor().Uint32())
+		o.sides[i] = win
+	}
+	return o
+}
+
+func (o *outline) show(geom xrect.Rect) {
+	x, y, w, h := xrect.Pieces(geom)
+	b := snapOutlineSize
+	sides := [4][4]int{
+		{x, y, w, b},
+		{x, y + h - b, w, b},
+		{x, y, b, h},
+		{x + w - b, y, b, h},
+	}
+	for i, win := range o.sides {
+		s := sides[i]
+		win.MoveResize(s[0], s[1], s[2], s[3])
+		win.Map()
+		win.Stack(xproto.StackModeAbove)
+	}
+}
+
+func (o *outline) hide() {
+	for _, win := range o.sides {
+		win.Unmap()
+	}
+}
@@ -3,7 +3,9 @@ package xclient
 import (
 	"time"
 
+	"github.com/BurntSushi/wingo/event"
 	"github.com/BurntSushi/wingo/frame"
+	"github.com/BurntSushi/wingo/hook"
 	"github.com/BurntSushi/wingo/layout"
 	"github.com/BurntSushi/wingo/stack"
 	"github.com/BurntSushi/wingo/wm"
@@ -25,6 +27,7 @@ func (c *Client) Float() {
 	if wrk, ok := c.Workspace().(*workspace.Workspace); ok {
 		c.floating = true
 		wrk.CheckFloatingStatus(c)
+		event.Notify(event.FloatedClient{Client: c.EventClient()})
 	}
 }
 
@@ -34,7 +37,9 @@ func (c *Client) Unfloat() {
 	}
 	if wrk, ok := c.Workspace().(*workspace.Workspace); ok {
 		c.floating = false
+		c.unsnap()
 		wrk.CheckFloatingStatus(c)
+		event.Notify(event.UnfloatedClient{Client: c.EventClient()})
 	}
 }
 
@@ -165,6 +170,9 @@ func (c *Client) Fullscreened() {
 
 	c.layer = stack.LayerFullscreen
 	c.Raise()
+
+	event.Notify(event.FullscreenedClient{Client: c.EventClient()})
+	c.FireHook(hook.Fullscreen)
 }
 
 func (c *Client) Unfullscreened() {
@@ -188,6 +196,8 @@ func (c *Client) Unfullscreened() {
 
 	c.layer = stack.LayerDefault
 	c.Raise()
+
+	event.Notify(event.UnfullscreenedClient{Client: c.EventClient()})
 }
 
 func (c *Client) MaximizeToggle() {
@@ -202,6 +212,7 @@ func (c *Client) Maximize() {
 	if !c.canMaxUnmax() {
 		return
 	}
+	c.unsnap()
 	if !c.IsMaximized() {
 		c.SaveState("before-maximize")
 		c.maximize()
@@ -212,6 +223,7 @@ func (c *Client) Unmaximize() {
 	if !c.canMaxUnmax() {
 		return
 	}
+	c.unsnap()
 	if c.IsMaximized() {
 		c.unmaximize()
 		c.LoadState("before-maximize")
@@ -243,6 +255,9 @@ func (c *Client) maximize() {
 
 	g := c.Workspace().Geom()
 	c.LayoutMoveResize(g.X(), g.Y(), g.Width(), g.Height())
+
+	event.Notify(event.MaximizedClient{Client: c.EventClient()})
+	c.FireHook(hook.Maximized)
 }
 
 func (c *Client) unmaximize() {
@@ -254,6 +269,8 @@ func (c *Client) unmaximize() {
 		c.removeState("_NET_WM_STATE_MAXIMIZE_HORZ")
 		c.removeState("_NET_WM_STATE_MAXIMIZE_VERT")
 		c.frames.unmaximize()
+
+		event.Notify(event.UnmaximizedClient{Client: c.EventClient()})
 	}
 }
 
@@ -314,6 +331,9 @@ func (c *Client) attnStart() {
 	}()
 
 	c.addState("_NET_WM_STATE_DEMANDS_ATTENTION")
+	event.Notify(event.ChangedClientUrgency{
+		Client: c.EventClient(), Urgent: true})
+	c.FireHook(hook.Urgent)
 }
 
 func (c *Client) attnStop() {
@@ -332,4 +352,6 @@ func (c *Client) attnStop() {
 	}
 
 	c.removeState("_NET_WM_STATE_DEMANDS_ATTENTION")
+	event.Notify(event.ChangedClientUrgency{
+		Client: c.EventClient(), Urgent: false})
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/BurntSushi/wingo/event"
 	"github.com/BurntSushi/wingo/focus"
+	"github.com/BurntSushi/wingo/hook"
 	"github.com/BurntSushi/wingo/logger"
 	"github.com/BurntSushi/wingo/stack"
 	"github.com/BurntSushi/wingo/wm"
@@ -28,10 +29,7 @@ func (c *Client) unmanage() {
 		logger.Message.Printf("Unmanaging client: %s", c)
 	}
 
-	infoWorkspace := c.workspace.String()
-	infoClass := c.Class().Class
-	infoInstance := c.Class().Instance
-	infoName := c.Name()
+	info := c.EventClient()
 
 	c.frame.Unmap()
 	c.win.Detach()
@@ -49,13 +47,8 @@ func (c *Client) unmanage() {
 		wm.Heads.ApplyStruts(wm.Clients)
 	}
 
-	event.Notify(event.UnmanagedClient{
-		Id:        c.Id(),
-		Name:      infoName,
-		Workspace: infoWorkspace,
-		Class:     infoClass,
-		Instance:  infoInstance,
-	})
+	event.Notify(event.UnmanagedClient{Client: info})
+	c.FireHook(hook.Unmanaged)
 }
 
 func (c *Client) ImminentDestruction() bool {
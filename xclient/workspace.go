@@ -5,6 +5,8 @@ import (
 
 	"github.com/BurntSushi/xgbutil/ewmh"
 
+	"github.com/BurntSushi/wingo/hook"
+	"github.com/BurntSushi/wingo/rules"
 	"github.com/BurntSushi/wingo/wm"
 	"github.com/BurntSushi/wingo/workspace"
 )
@@ -12,12 +14,21 @@ import (
 // ShouldForceFloating returns true whenever a client should be floating.
 // More specifically, it returns true when a client should NOT be added to
 // a tiling layout even if a tiling layout is active.
+//
+// A rule with "floating := no" lets transient, fixed size and splash windows
+// be tiled. Docks and desktop windows are never tiled.
 func (c *Client) ShouldForceFloating() bool {
-	return c.floating ||
+	if c.floating ||
 		c.sticky ||
 		c.fullscreen ||
-		c.transientFor != nil ||
-		c.PrimaryType() != TypeNormal ||
+		c.PrimaryType() != TypeNormal {
+
+		return true
+	}
+	if c.rules.Floating == rules.Off {
+		return false
+	}
+	return c.transientFor != nil ||
 		c.isFixedSize() ||
 		c.hasType("_NET_WM_WINDOW_TYPE_SPLASH")
 }
@@ -27,6 +38,7 @@ func (c *Client) Workspace() workspace.Workspacer {
 }
 
 func (c *Client) WorkspaceSet(newWrk workspace.Workspacer) {
+	old := c.workspace
 	c.workspace = newWrk
 
 	switch wrk := c.workspace.(type) {
@@ -34,6 +46,15 @@ func (c *Client) WorkspaceSet(newWrk workspace.Workspacer) {
 		ewmh.WmDesktopSet(wm.X, c.Id(), 0xFFFFFFFF)
 	case *workspace.Workspace:
 		ewmh.WmDesktopSet(wm.X, c.Id(), uint(wm.Heads.GlobalIndex(wrk)))
+
+		// A client being managed doesn't have a workspace yet, and so
+		// doesn't count as moving.
+		if old != nil && old != newWrk {
+			hook.Fire(hook.ClientMovedWorkspace, hook.Args{
+				Client:    fmt.Sprintf("%d", c.Id()),
+				Workspace: wrk.Name,
+			})
+		}
 	default:
 		panic(fmt.Sprintf("Unknown workspace type: %T", wrk))
 	}